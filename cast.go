@@ -8,8 +8,10 @@ package fuego
 //
 // See C for A typed cast.
 func SC[U any](from Stream[Any], to Stream[U]) Stream[U] {
+	from = from.Async()
 	toCh := make(chan U, from.concurrency)
 	to.stream = toCh
+	to = planStage(from, to, "SC")
 
 	go func() {
 		defer close(to.stream)
@@ -30,19 +32,7 @@ func SC[U any](from Stream[Any], to Stream[U]) Stream[U] {
 //
 // See SC for A Stream cast.
 func C[U any](from Stream[Any], to U) Stream[U] {
-	toCh := make(chan U, from.concurrency)
-
-	toStream := NewConcurrentStream(toCh, from.concurrency)
-
-	go func() {
-		defer close(toStream.stream)
-
-		for f := range from.stream {
-			toStream.stream <- interface{}(f).(U)
-		}
-	}()
-
-	return toStream
+	return cast[U](from)
 }
 
 // CC is a typed cast function from a non-parameterised Stream[Any] to a parameterised type ComparableStream[U].
@@ -51,19 +41,7 @@ func C[U any](from Stream[Any], to U) Stream[U] {
 // CC exists to address the current lack of support in Go for parameterised methods and a performance issue with Go 1.18.
 // See doc.go for more details.
 func CC[U Comparable](from Stream[Any], to U) ComparableStream[U] {
-	toCh := make(chan U, from.concurrency)
-
-	toStream := NewConcurrentStream(toCh, from.concurrency)
-
-	go func() {
-		defer close(toStream.stream)
-
-		for f := range from.stream {
-			toStream.stream <- interface{}(f).(U)
-		}
-	}()
-
-	return ComparableStream[U]{toStream}
+	return ComparableStream[U]{cast[U](from)}
 }
 
 // MC is a typed cast function from a non-parameterised Stream[Any] to a parameterised type MathableStream[U].
@@ -72,9 +50,24 @@ func CC[U Comparable](from Stream[Any], to U) ComparableStream[U] {
 // MC exists to address the current lack of support in Go for parameterised methods and a performance issue with Go 1.18.
 // See doc.go for more details.
 func MC[U Mathable](from Stream[Any], to U) MathableStream[U] {
+	return MathableStream[U]{cast[U](from)}
+}
+
+// cast converts the elements of a Stream[Any] to type U.
+//
+// A synchronous stream remains synchronous. See Stream.Async.
+func cast[U any](from Stream[Any]) Stream[U] {
+	if from.pull != nil {
+		return planStage(from, derivedPullStream(from, pullMap(from.pull, func(f Any) U {
+			return interface{}(f).(U)
+		})), "C")
+	}
+
+	from = from.Async() // in case its stages were fused with another stream.
+
 	toCh := make(chan U, from.concurrency)
 
-	toStream := NewConcurrentStream(toCh, from.concurrency)
+	toStream := planStage(from, derivedStream(from, toCh), "C")
 
 	go func() {
 		defer close(toStream.stream)
@@ -84,5 +77,5 @@ func MC[U Mathable](from Stream[Any], to U) MathableStream[U] {
 		}
 	}()
 
-	return MathableStream[U]{toStream}
+	return toStream
 }
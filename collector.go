@@ -1,6 +1,6 @@
 package fuego
 
-import "fmt"
+import "sync"
 
 // NOTICE:
 // The code in this file was inspired by Java Collectors,
@@ -21,28 +21,32 @@ import "fmt"
 // implement you own requirement functionally! Focus on *what* needs to be done in your streams (and
 // delegate the details of the *how* to the implementation of your `Collector`).
 //
+// A concurrent Collector (see NewConcurrentCollector and Collector.Concurrent) collects the
+// elements of a concurrent Stream in parallel. See Collect.
+//
 // Type T: type of input elements to the reduction operation
 // Type A: mutable accumulation type of the reduction operation (often hidden as an implementation detail)
 // Type R: result type of the reduction operation.
 type Collector[T, A, R any] struct {
 	supplier    Supplier[A]
 	accumulator BiFunction[A, T, A]
-	// combiner BiFunction[A]/BinaryOperator[A] // this is for joining parallel collectors
-	finisher Function[A, R]
+	combiner    BiFunction[A, A, A] // this is for joining parallel collectors
+	finisher    Function[A, R]
+	concurrent  bool
 }
 
 // NewCollector creates a new Collector.
 func NewCollector[T, A, R any](supplier Supplier[A], accumulator BiFunction[A, T, A], finisher Function[A, R]) Collector[T, A, R] {
 	if supplier == nil {
-		panic(PanicCollectorMissingSupplier)
+		panic(ErrCollectorMissingSupplier)
 	}
 
 	if accumulator == nil {
-		panic(PanicCollectorMissingAccumulator)
+		panic(ErrCollectorMissingAccumulator)
 	}
 
 	if finisher == nil {
-		panic(PanicCollectorMissingFinisher)
+		panic(ErrCollectorMissingFinisher)
 	}
 
 	return Collector[T, A, R]{
@@ -52,12 +56,57 @@ func NewCollector[T, A, R any](supplier Supplier[A], accumulator BiFunction[A, T
 	}
 }
 
+// NewConcurrentCollector creates a new Collector that collects the elements of a
+// concurrent Stream in parallel (see Collect).
+//
+// The combiner merges two partial accumulations into one. It must be associative and
+// the result of the Collector must not depend on the order of the elements since the
+// partial accumulations are made of elements taken in any order from the stream.
+// The supplier and the accumulator must be safe for concurrent use.
+func NewConcurrentCollector[T, A, R any](supplier Supplier[A], accumulator BiFunction[A, T, A], combiner BiFunction[A, A, A], finisher Function[A, R]) Collector[T, A, R] {
+	if combiner == nil {
+		panic(ErrCollectorMissingCombiner)
+	}
+
+	c := NewCollector(supplier, accumulator, finisher)
+	c.combiner = combiner
+
+	return c.Concurrent()
+}
+
+// Concurrent returns a concurrent copy of this Collector, which collects the elements
+// of a concurrent Stream in parallel (see Collect). It panics with
+// ErrCollectorMissingCombiner when this Collector has no combiner.
+//
+// The built-in Collectors such as ToSlice, ToMap or Reducing have a combiner but are
+// not concurrent, which preserves the order of the elements. Once made concurrent,
+// the result must not depend on the order of the elements and the functions supplied
+// to the Collector (e.g. the classifier of GroupingBy) must be safe for concurrent use.
+// For instance, ToSlice[T]().Concurrent() returns the elements in any order.
+func (c Collector[T, A, R]) Concurrent() Collector[T, A, R] {
+	if c.combiner == nil {
+		panic(ErrCollectorMissingCombiner)
+	}
+
+	c.concurrent = true
+
+	return c
+}
+
+// IsConcurrent returns true when this Collector collects the elements of a concurrent
+// Stream in parallel.
+func (c Collector[T, A, R]) IsConcurrent() bool {
+	return c.concurrent
+}
+
 // type MutationCollector func(Function, Collector) Collector
 // type Collecting func(MutationCollector) MutationCollector
 
 // GroupingBy groups the elements of the downstream Collector
 // by classifying them with the provided classifier function.
 //
+// The Collector has a combiner (see Collector.Concurrent) when the downstream Collector has one.
+//
 // Type T: the type of the input elements
 // Type K: the type of the keys
 // Type A: the intermediate accumulation type of the downstream collector
@@ -91,10 +140,28 @@ func GroupingBy[T any, K comparable, A, D any](classifier Function[T, K], downst
 		return m
 	}
 
-	return NewCollector(supplier, accumulator, finisher)
+	if downstream.combiner == nil {
+		return NewCollector(supplier, accumulator, finisher)
+	}
+
+	c := NewCollector(supplier, accumulator, finisher)
+	c.combiner = func(e1, e2 map[K]A) map[K]A {
+		for k, v2 := range e2 {
+			if v1, ok := e1[k]; ok {
+				v2 = downstream.combiner(v1, v2)
+			}
+
+			e1[k] = v2
+		}
+
+		return e1
+	}
+
+	return c
 }
 
 // Mapping adapts a Collector with elements of type U to a collector with elements of type T.
+// The Collector has a combiner (see Collector.Concurrent) when the downstream Collector has one.
 func Mapping[T, U, A, R any](mapper Function[T, U], downstream Collector[U, A, R]) Collector[T, A, R] {
 	supplier := downstream.supplier
 
@@ -104,11 +171,15 @@ func Mapping[T, U, A, R any](mapper Function[T, U], downstream Collector[U, A, R
 
 	finisher := downstream.finisher
 
-	return NewCollector(supplier, accumulator, finisher)
+	c := NewCollector(supplier, accumulator, finisher)
+	c.combiner = downstream.combiner
+
+	return c
 }
 
 // FlatMapping adapts the Entries a Collector accepts to another type by
 // applying a flat mapping function which maps input elements to a `Stream`.
+// The Collector has a combiner (see Collector.Concurrent) when the downstream Collector has one.
 func FlatMapping[U, T, A, R any](mapper StreamFunction[T, U], collector Collector[U, A, R]) Collector[T, A, R] {
 	supplier := collector.supplier
 
@@ -126,10 +197,14 @@ func FlatMapping[U, T, A, R any](mapper StreamFunction[T, U], collector Collecto
 
 	finisher := collector.finisher
 
-	return NewCollector(supplier, accumulator, finisher)
+	c := NewCollector(supplier, accumulator, finisher)
+	c.combiner = collector.combiner
+
+	return c
 }
 
 // Filtering filters the entries a Collector accepts to a subset that satisfy the given predicate.
+// The Collector has a combiner (see Collector.Concurrent) when the downstream Collector has one.
 func Filtering[T, A, R any](predicate Predicate[T], collector Collector[T, A, R]) Collector[T, A, R] {
 	supplier := collector.supplier
 
@@ -143,11 +218,17 @@ func Filtering[T, A, R any](predicate Predicate[T], collector Collector[T, A, R]
 
 	finisher := collector.finisher
 
-	return NewCollector(supplier, accumulator, finisher)
+	c := NewCollector(supplier, accumulator, finisher)
+	c.combiner = collector.combiner
+
+	return c
 }
 
 // Reducing returns a collector that performs a reduction of
 // its input elements using the provided BiFunction.
+//
+// f2 must be associative and commutative when the Collector is made concurrent
+// (see Collector.Concurrent).
 func Reducing[T any](f2 BiFunction[T, T, T]) Collector[T, Optional[T], T] {
 	supplier := func() Optional[T] {
 		return OptionalEmpty[T]()
@@ -161,16 +242,35 @@ func Reducing[T any](f2 BiFunction[T, T, T]) Collector[T, Optional[T], T] {
 		return OptionalOf(result)
 	}
 
+	combiner := func(e1, e2 Optional[T]) Optional[T] {
+		if !e1.IsPresent() {
+			return e2
+		}
+
+		if !e2.IsPresent() {
+			return e1
+		}
+
+		return OptionalOf(f2(e1.Get(), e2.Get()))
+	}
+
 	finisher := func(e Optional[T]) T {
 		// alternative:
 		// return e.OrElse(*new(T))
 		return e.Get()
 	}
 
-	return NewCollector(supplier, accumulator, finisher)
+	c := NewCollector(supplier, accumulator, finisher)
+	c.combiner = combiner
+
+	return c
 }
 
 // ToSlice returns a collector that accumulates the input entries into a Go slice.
+//
+// The order of the elements in the slice is that of the stream, unless the Collector
+// is made concurrent (see Collector.Concurrent).
+//
 // Type T: type of the elements accumulated in the slice.
 func ToSlice[T any]() Collector[T, []T, []T] {
 	supplier := func() []T { // TODO: use chan A instead with a finisher that converts to []A?
@@ -181,12 +281,22 @@ func ToSlice[T any]() Collector[T, []T, []T] {
 		return append(supplier, element)
 	}
 
+	combiner := func(e1, e2 []T) []T {
+		return append(e1, e2...)
+	}
+
 	finisher := IdentityFinisher[[]T]
 
-	return NewCollector(supplier, accumulator, finisher)
+	c := NewCollector(supplier, accumulator, finisher)
+	c.combiner = combiner
+
+	return c
 }
 
 // ToMap returns a collector that accumulates the input entries into a Go map.
+// It panics with a DuplicateKeyError when two entries have the same key: see TryCollect
+// to obtain the error instead.
+// The Collector can be made concurrent (see Collector.Concurrent).
 // Type T: type from which the elements are accumulated in the map.
 // Type K: type of the keys derived from T.
 // Type V: type of the values derived from T.
@@ -204,16 +314,33 @@ func ToMap[T any, K comparable, V any](keyMapper Function[T, K], valueMapper Fun
 			return supplier
 		}
 
-		panic(fmt.Sprintf("%s: '%v'", PanicDuplicateKey, key))
+		panic(DuplicateKeyError{Key: key})
+	}
+
+	combiner := func(e1, e2 map[K]V) map[K]V {
+		for key, value := range e2 {
+			if _, ok := e1[key]; ok {
+				panic(DuplicateKeyError{Key: key})
+			}
+
+			e1[key] = value
+		}
+
+		return e1
 	}
 
 	finisher := IdentityFinisher[map[K]V]
 
-	return NewCollector(supplier, accumulator, finisher)
+	c := NewCollector(supplier, accumulator, finisher)
+	c.combiner = combiner
+
+	return c
 }
 
 // ToMapWithMerge returns a collector that accumulates the input entries into a Go map.
 // Key collision strategy is managed by mergeFn.
+// mergeFn must be associative and commutative when the Collector is made concurrent
+// (see Collector.Concurrent).
 // Type T: type from which the elements are accumulated in the map.
 // Type K: type of the keys derived from T.
 // Type V: type of the values derived from T.
@@ -236,9 +363,24 @@ func ToMapWithMerge[T any, K comparable, V any](keyMapper Function[T, K], valueM
 		return supplier
 	}
 
+	combiner := func(e1, e2 map[K]V) map[K]V {
+		for key, value := range e2 {
+			if v1, ok := e1[key]; ok {
+				value = mergeFn(v1, value)
+			}
+
+			e1[key] = value
+		}
+
+		return e1
+	}
+
 	finisher := IdentityFinisher[map[K]V]
 
-	return NewCollector(supplier, accumulator, finisher)
+	c := NewCollector(supplier, accumulator, finisher)
+	c.combiner = combiner
+
+	return c
 }
 
 // IdentityFinisher is a basic finisher that returns the
@@ -249,15 +391,25 @@ func IdentityFinisher[T any](t T) T {
 
 // Collect reduces and optionally mutates the stream with the supplied Collector.
 //
+// The elements are accumulated in the order of the stream, unless the Collector is
+// concurrent (see Collector.Concurrent) and the stream has a level of concurrency greater
+// than 1: the elements are then accumulated in parallel into Concurrency() partial
+// accumulations, which are then merged with the combiner of the Collector. Each partial
+// accumulation receives the elements in any order.
+//
 // This is a continuous terminal operation and hence expects
 // the producer to close the stream in order to complete.
 func Collect[T, A, R any](s Stream[T], c Collector[T, A, R]) R {
-	if s.stream == nil {
-		panic(PanicMissingChannel)
+	if s.isNil() {
+		panic(ErrMissingChannel)
+	}
+
+	if c.concurrent && s.concurrency > 1 {
+		return c.finisher(collectConcurrently(s, c))
 	}
 
 	result := c.supplier()
-	for e := range s.stream {
+	for e, ok := s.receive(); ok; e, ok = s.receive() {
 		result = c.accumulator(result, e)
 	}
 
@@ -265,3 +417,87 @@ func Collect[T, A, R any](s Stream[T], c Collector[T, A, R]) R {
 
 	return finishedResult
 }
+
+// collectConcurrently accumulates the elements of the stream into Concurrency() partial
+// accumulations and combines them.
+//
+// A panic of the Collector is resumed in the calling Go routine, once all the elements
+// have been consumed.
+func collectConcurrently[T, A, R any](s Stream[T], c Collector[T, A, R]) A {
+	s = s.Async()
+
+	partials := make([]A, s.concurrency)
+	panics := make([]any, s.concurrency)
+
+	wg := sync.WaitGroup{}
+	wg.Add(s.concurrency)
+
+	for i := range partials {
+		go func(i int) {
+			defer wg.Done()
+
+			defer func() {
+				if r := recover(); r != nil {
+					panics[i] = r
+
+					for range s.stream { // nolint: revive
+					}
+				}
+			}()
+
+			partial := c.supplier()
+			for e := range s.stream {
+				partial = c.accumulator(partial, e)
+			}
+
+			partials[i] = partial
+		}(i)
+	}
+
+	wg.Wait()
+	s.failure.resume()
+
+	for _, r := range panics {
+		if r != nil {
+			panic(r)
+		}
+	}
+
+	result := partials[0]
+	for _, partial := range partials[1:] {
+		result = c.combiner(result, partial)
+	}
+
+	return result
+}
+
+// TryCollect reduces and optionally mutates the stream with the supplied Collector,
+// like Collect does.
+//
+// Unlike Collect, it does not panic with the errors of fuego: it returns them instead,
+// e.g. ErrMissingChannel when the stream has no channel, or a DuplicateKeyError when
+// the Collector is ToMap. Other panics, such as those of user functions, are not
+// recovered. When an error is returned, the remaining elements of the stream are
+// released (see Stream.All).
+//
+// Note that the errors of fuego are returned wherever they are raised, including
+// within user functions: e.g. a RetriesExhaustedError of Stream.MapWithRetry, but
+// also ErrNoSuchElement when a mapper calls Optional.Get on an empty Optional.
+//
+// This is a continuous terminal operation and hence expects
+// the producer to close the stream in order to complete.
+func TryCollect[T, A, R any](s Stream[T], c Collector[T, A, R]) (result R, err error) {
+	if s.isNil() {
+		return result, ErrMissingChannel
+	}
+
+	defer func() {
+		if err != nil {
+			s.release()
+		}
+	}()
+
+	defer recoverError(&err)
+
+	return Collect(s, c), nil
+}
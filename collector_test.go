@@ -2,8 +2,12 @@ package fuego
 
 import (
 	"hash/crc32"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -61,7 +65,7 @@ func TestCollector_Collect_ToEntryMap(t *testing.T) {
 	tt := map[string]struct {
 		inputData     []employee
 		expected      map[string]int
-		expectedPanic string
+		expectedPanic error
 	}{
 		"panics when key exists": {
 			inputData: []employee{
@@ -74,7 +78,7 @@ func TestCollector_Collect_ToEntryMap(t *testing.T) {
 					name: "One",
 				},
 			},
-			expectedPanic: PanicDuplicateKey + ": 'One'",
+			expectedPanic: DuplicateKeyError{Key: "One"},
 		},
 		"returns a map of employee (name, id)": {
 			inputData: getEmployeesSample(),
@@ -98,7 +102,7 @@ func TestCollector_Collect_ToEntryMap(t *testing.T) {
 				)
 			}
 
-			if tc.expectedPanic != "" {
+			if tc.expectedPanic != nil {
 				assert.PanicsWithValue(t, tc.expectedPanic, func() { _ = employeeNameByID() })
 				return
 			}
@@ -108,6 +112,57 @@ func TestCollector_Collect_ToEntryMap(t *testing.T) {
 	}
 }
 
+func TestTryCollect(t *testing.T) {
+	tt := map[string]struct {
+		stream  Stream[employee]
+		want    map[string]int
+		wantErr error
+	}{
+		"Should return error for a Stream of nil": {
+			stream:  Stream[employee]{},
+			wantErr: ErrMissingChannel,
+		},
+		"Should return error when key exists": {
+			stream:  NewStreamFromSlice([]employee{{id: 1, name: "One"}, {id: 1000, name: "One"}, {id: 2, name: "Two"}}, 0),
+			wantErr: DuplicateKeyError{Key: "One"},
+		},
+		"Should return a map of employee (name, id)": {
+			stream: NewStream(chanOf(getEmployeesSample())),
+			want:   map[string]int{"One": 1, "Two": 2, "Three": 3, "Four": 4, "Five": 5},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got, err := TryCollect(tc.stream, ToMap(employee.Name, employee.ID))
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTryCollect_DoesNotRecoverOtherPanics(t *testing.T) {
+	c := NewCollector(
+		func() int { return 0 },
+		func(int, int) int { panic("boom") },
+		IdentityFinisher[int],
+	)
+
+	assert.PanicsWithValue(t, "boom", func() { _, _ = TryCollect(NewStreamFromSlice([]int{1}, 0), c) })
+}
+
+func TestTryCollect_ReturnsErrorsOfUserFunctions(t *testing.T) {
+	s := Map(NewStreamFromSlice([]int{1, 2}, 0).Concurrent(2), func(i int) int {
+		return OptionalEmpty[int]().Get()
+	})
+
+	got, err := TryCollect(s, ToSlice[int]())
+	assert.ErrorIs(t, err, ErrNoSuchElement)
+	assert.Nil(t, got)
+}
+
 func TestCollector_Collect_ToEntryMapWithKeyMerge(t *testing.T) {
 	employees := getEmployeesSample()
 
@@ -270,6 +325,149 @@ func TestCollector_GroupingBy_Mapping_FlatMapping_Filtering_Mapping_Reducing(t *
 	assert.EqualValues(t, expected, got)
 }
 
+func TestNewConcurrentCollector(t *testing.T) {
+	assert.PanicsWithValue(t, ErrCollectorMissingCombiner, func() {
+		NewConcurrentCollector(func() int { return 0 }, Sum[int], nil, IdentityFinisher[int])
+	})
+
+	c := NewConcurrentCollector(func() int { return 0 }, Sum[int], Sum[int], IdentityFinisher[int])
+	assert.True(t, c.IsConcurrent())
+	assert.False(t, NewCollector(func() int { return 0 }, Sum[int], IdentityFinisher[int]).IsConcurrent())
+}
+
+func TestCollector_Concurrent(t *testing.T) {
+	assert.PanicsWithValue(t, ErrCollectorMissingCombiner, func() {
+		NewCollector(func() int { return 0 }, Sum[int], IdentityFinisher[int]).Concurrent()
+	})
+
+	c := ToSlice[int]()
+	assert.False(t, c.IsConcurrent())
+	assert.True(t, c.Concurrent().IsConcurrent())
+	assert.False(t, c.IsConcurrent())
+
+	assert.False(t, Reducing(Sum[int]).IsConcurrent())
+	assert.False(t, GroupingBy(Identity[int], Summarizing[int]()).IsConcurrent())
+	assert.True(t, GroupingBy(Identity[int], Summarizing[int]()).Concurrent().IsConcurrent())
+}
+
+func TestCollect_ConcurrentStreamPreservesOrder(t *testing.T) {
+	data := intRange(1000)
+
+	t.Run("Map then ToSlice", func(t *testing.T) {
+		got := Collect(Map(NewStreamFromSlice(data, 10).Concurrent(4), func(i int) int { return i * 2 }), ToSlice[int]())
+
+		want := make([]int, len(data))
+		for idx, val := range data {
+			want[idx] = val * 2
+		}
+
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("Reducing", func(t *testing.T) {
+		concat := func(s1, s2 string) string { return s1 + s2 }
+		got := Collect(Map(NewStreamFromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8, 9}, 0).Concurrent(4), strconv.Itoa), Reducing(concat))
+		assert.Equal(t, "123456789", got)
+	})
+
+	t.Run("GroupingBy", func(t *testing.T) {
+		got := Collect(NewStreamFromSlice(data, 0).Concurrent(4), GroupingBy(func(i int) int { return i % 2 }, ToSlice[int]()))
+		assert.Equal(t, data[0], got[0][0])
+		assert.True(t, sort.IntsAreSorted(got[0]))
+		assert.True(t, sort.IntsAreSorted(got[1]))
+	})
+}
+
+func TestCollect_Concurrent(t *testing.T) {
+	stringLength := func(el string) int { return len(el) }
+	strs := []string{"a", "bb", "cc", "ddd", "ee", "f", "ggg", "h"}
+
+	t.Run("ToSlice", func(t *testing.T) {
+		got := Collect(NewStreamFromSlice(intRange(100), 0).Concurrent(4), ToSlice[int]().Concurrent())
+		assert.ElementsMatch(t, intRange(100), got)
+	})
+
+	t.Run("ToMap", func(t *testing.T) {
+		got := Collect(NewStreamFromSlice(intRange(100), 0).Concurrent(4), ToMap(Identity[int], strconv.Itoa).Concurrent())
+		assert.Len(t, got, 100)
+	})
+
+	t.Run("ToMap with duplicate key", func(t *testing.T) {
+		_, err := TryCollect(NewStreamFromSlice(append(intRange(100), 50), 0).Concurrent(4), ToMap(Identity[int], Identity[int]).Concurrent())
+		assert.Equal(t, DuplicateKeyError{Key: 50}, err)
+	})
+
+	t.Run("ToMapWithMerge", func(t *testing.T) {
+		got := Collect(NewStreamFromSlice(strs, 0).Concurrent(3), ToMapWithMerge(stringLength, func(string) int { return 1 }, Sum[int]).Concurrent())
+		assert.Equal(t, map[int]int{1: 3, 2: 3, 3: 2}, got)
+	})
+
+	t.Run("GroupingBy", func(t *testing.T) {
+		got := Collect(NewStreamFromSlice(strs, 0).Concurrent(3),
+			GroupingBy(stringLength, Mapping(strings.ToUpper, Filtering(func(s string) bool { return s != "H" }, ToSlice[string]()))).Concurrent())
+		assert.Len(t, got, 3)
+		assert.ElementsMatch(t, []string{"A", "F"}, got[1])
+		assert.ElementsMatch(t, []string{"BB", "CC", "EE"}, got[2])
+		assert.ElementsMatch(t, []string{"DDD", "GGG"}, got[3])
+	})
+
+	t.Run("Reducing", func(t *testing.T) {
+		got := Collect(NewStreamFromSlice(intRange(101), 0).Concurrent(4), Reducing(Sum[int]).Concurrent())
+		assert.Equal(t, 5050, got)
+	})
+
+	t.Run("non-concurrent Collector", func(t *testing.T) {
+		got := Collect(NewStreamFromSlice([]int{1, 2, 3}, 0).Concurrent(4), ReservoirSample[int](3, seededRand()))
+		assert.ElementsMatch(t, []int{1, 2, 3}, got)
+	})
+}
+
+func TestCollect_Concurrent_IsConcurrent(t *testing.T) {
+	const workers = 3
+
+	barrier := sync.WaitGroup{}
+	barrier.Add(workers)
+
+	// each partial accumulation waits for all the others to have started:
+	// this would never complete if the accumulations were not concurrent.
+	c := NewConcurrentCollector(
+		func() int {
+			barrier.Done()
+			barrier.Wait()
+			return 0
+		},
+		Sum[int],
+		Sum[int],
+		IdentityFinisher[int],
+	)
+
+	done := make(chan int)
+	go func() { done <- Collect(NewStreamFromSlice(intRange(10), 0).Concurrent(workers), c) }()
+
+	select {
+	case got := <-done:
+		assert.Equal(t, 45, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("partial accumulations are not concurrent")
+	}
+}
+
+func TestCollect_Concurrent_ResumesPanic(t *testing.T) {
+	c := NewConcurrentCollector(
+		func() int { return 0 },
+		func(acc, i int) int {
+			if i == 50 {
+				panic("boom")
+			}
+			return acc + i
+		},
+		Sum[int],
+		IdentityFinisher[int],
+	)
+
+	assert.PanicsWithValue(t, "boom", func() { Collect(NewStreamFromSlice(intRange(100), 0).Concurrent(4), c) })
+}
+
 type employee struct {
 	id         int
 	name       string
@@ -6,18 +6,18 @@ type ComparableStream[T Comparable] struct {
 }
 
 func (s ComparableStream[T]) Max() T {
-	if s.stream == nil {
-		panic(PanicMissingChannel)
+	if s.isNil() {
+		panic(ErrMissingChannel)
 	}
 
-	val, ok := <-s.stream
+	val, ok := s.receive()
 	if !ok {
-		panic(PanicNoSuchElement)
+		panic(ErrNoSuchElement)
 	}
 
 	max := val
 
-	for val = range s.stream {
+	for val, ok = s.receive(); ok; val, ok = s.receive() {
 		max = Max(max, val)
 	}
 
@@ -25,20 +25,38 @@ func (s ComparableStream[T]) Max() T {
 }
 
 func (s ComparableStream[T]) Min() T {
-	if s.stream == nil {
-		panic(PanicMissingChannel)
+	if s.isNil() {
+		panic(ErrMissingChannel)
 	}
 
-	val, ok := <-s.stream
+	val, ok := s.receive()
 	if !ok {
-		panic(PanicNoSuchElement)
+		panic(ErrNoSuchElement)
 	}
 
 	min := val
 
-	for val = range s.stream {
+	for val, ok = s.receive(); ok; val, ok = s.receive() {
 		min = Min(min, val)
 	}
 
 	return min
 }
+
+// MaxOptional returns an Optional describing the greatest element of this stream,
+// or an empty Optional if the stream is empty.
+//
+// This is a continuous terminal operation and hence expects the producer to close
+// the stream in order to complete.
+func (s ComparableStream[T]) MaxOptional() Optional[T] {
+	return s.ReduceOptional(Max[T])
+}
+
+// MinOptional returns an Optional describing the smallest element of this stream,
+// or an empty Optional if the stream is empty.
+//
+// This is a continuous terminal operation and hence expects the producer to close
+// the stream in order to complete.
+func (s ComparableStream[T]) MinOptional() Optional[T] {
+	return s.ReduceOptional(Min[T])
+}
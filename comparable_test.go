@@ -101,3 +101,31 @@ func TestComparableStream_Min(t *testing.T) {
 		})
 	}
 }
+
+func TestComparableStream_MaxOptional_MinOptional(t *testing.T) {
+	tt := map[string]struct {
+		elements []int
+		wantMax  Optional[int]
+		wantMin  Optional[int]
+	}{
+		"Should return empty Optional for an empty Stream": {
+			elements: []int{},
+			wantMax:  OptionalEmpty[int](),
+			wantMin:  OptionalEmpty[int](),
+		},
+		"Should return the max and min": {
+			elements: []int{4, 1, 7, -2, 3},
+			wantMax:  OptionalOf(7),
+			wantMin:  OptionalOf(-2),
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.wantMax, ComparableStream[int]{NewStreamFromSlice(tc.elements, 0)}.MaxOptional())
+			assert.Equal(t, tc.wantMin, ComparableStream[int]{NewStreamFromSlice(tc.elements, 0)}.MinOptional())
+		})
+	}
+}
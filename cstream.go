@@ -0,0 +1,221 @@
+package fuego
+
+import "sync"
+
+// CStream is a group of Streams processed concurrently.
+//
+// The methods of CStream apply to each member Stream. Each member is processed in its own
+// Go routine(s) and with its own settings: for instance, CStream.Map performs a concurrent
+// Map on the members that are concurrent (see Stream.Concurrent).
+//
+// The members are typically obtained by splitting a Stream (e.g. Stream.Tee or
+// Stream.Route) or from independent sources. Concat and Merge combine them back into
+// a single Stream.
+type CStream[T any] struct {
+	streams []Stream[T]
+}
+
+// NewCStream creates a new CStream with the given member Streams.
+//
+// Synchronous members (see Stream.Async) are made asynchronous so that they are
+// processed concurrently with one another.
+func NewCStream[T any](streams ...Stream[T]) CStream[T] {
+	members := make([]Stream[T], len(streams))
+	for idx, s := range streams {
+		members[idx] = s.Async()
+	}
+
+	return CStream[T]{
+		streams: members,
+	}
+}
+
+// Streams returns the member Streams of this CStream.
+func (cs CStream[T]) Streams() []Stream[T] {
+	return append([]Stream[T]{}, cs.streams...)
+}
+
+// Concurrent sets the level of concurrency of every member Stream.
+//
+// See Stream.Concurrent.
+func (cs CStream[T]) Concurrent(n int) CStream[T] {
+	return cs.apply(func(s Stream[T]) Stream[T] {
+		return s.Concurrent(n)
+	})
+}
+
+// Filter returns a CStream whose members consist of the elements of the members
+// of this CStream that match the given predicate.
+//
+// The predicate is applied concurrently to the members. It must therefore be safe
+// for concurrent use.
+//
+// See Stream.Filter.
+func (cs CStream[T]) Filter(predicate Predicate[T]) CStream[T] {
+	return cs.apply(func(s Stream[T]) Stream[T] {
+		return s.Filter(predicate)
+	})
+}
+
+// Map returns a CStream whose members consist of the result of applying the given
+// function to the elements of the members of this CStream.
+//
+// The mapper is applied concurrently to the members. It must therefore be safe
+// for concurrent use.
+//
+// See Stream.Map and MapCStream.
+func (cs CStream[T]) Map(mapper Function[T, Any]) CStream[Any] {
+	return MapCStream(cs, mapper)
+}
+
+// MapCStream returns a CStream whose members consist of the result of applying the
+// given function to the elements of the members of the CStream.
+//
+// This is the typed equivalent of CStream.Map. See Map for details.
+func MapCStream[T, R any](cs CStream[T], mapper Function[T, R]) CStream[R] {
+	streams := make([]Stream[R], len(cs.streams))
+	for idx, s := range cs.streams {
+		streams[idx] = Map(s, mapper)
+	}
+
+	return CStream[R]{
+		streams: streams,
+	}
+}
+
+// ForEach executes the given consumer function for each element of the members of
+// this CStream. The members are consumed concurrently.
+//
+// The consumer must be safe for concurrent use.
+//
+// Should the consumer panic, the remaining elements are discarded, the members are
+// released and the first panic is resumed in the calling Go routine once all the
+// members are consumed.
+//
+// This is a continuous terminal operation. It will only complete if the producers
+// of all the members close their streams.
+func (cs CStream[T]) ForEach(c Consumer[T]) {
+	f := newFailure()
+
+	consumer := func(val T) {
+		if !f.hasFailed() {
+			c(val)
+		}
+	}
+
+	cs.run(f, func(s Stream[T]) { s.ForEach(consumer) })
+	f.resume()
+}
+
+// Concat combines the members of this CStream into a single Stream ordered by member:
+// the elements of the first member, then those of the second member, and so forth.
+//
+// The members continue to be processed concurrently, but a member is only read once
+// the previous members are exhausted: their processing stalls when their buffer is full.
+//
+// The resulting Stream has the settings (such as concurrency) of the first member.
+func (cs CStream[T]) Concat() Stream[T] {
+	return cs.combine("Concat", func(members []Stream[T], outstream chan<- T) {
+		for _, s := range members {
+			for val, ok := s.receive(); ok; val, ok = s.receive() {
+				outstream <- val
+			}
+		}
+	})
+}
+
+// Merge combines the members of this CStream into a single Stream. The elements are
+// emitted as soon as they are available, whichever member they come from. The order
+// of the elements of each member is preserved.
+//
+// The resulting Stream has the settings (such as concurrency) of the first member.
+func (cs CStream[T]) Merge() Stream[T] {
+	return cs.combine("Merge", func(members []Stream[T], outstream chan<- T) {
+		f := newFailure()
+
+		CStream[T]{streams: members}.run(f, func(s Stream[T]) {
+			for val, ok := s.receiveUntilFailed(); ok && !f.hasFailed(); val, ok = s.receiveUntilFailed() {
+				outstream <- val
+			}
+
+			s.failure.resume()
+		})
+
+		f.resume()
+	})
+}
+
+// run calls fn with each of the members of this CStream in its own Go routine and
+// waits for all of them to return. The first panic is recorded by f, upon which
+// the members are released.
+func (cs CStream[T]) run(f *failure, fn func(Stream[T])) {
+	wg := sync.WaitGroup{}
+	wg.Add(len(cs.streams))
+
+	once := sync.Once{}
+
+	for _, s := range cs.streams {
+		go func(s Stream[T]) {
+			defer wg.Done()
+
+			f.run(func() { fn(s) })
+
+			if f.hasFailed() {
+				once.Do(func() {
+					for _, member := range cs.streams {
+						member.release()
+					}
+				})
+			}
+		}(s)
+	}
+
+	wg.Wait()
+}
+
+// apply returns a CStream made of the result of the given function applied to each
+// of the members of this CStream.
+func (cs CStream[T]) apply(fn func(Stream[T]) Stream[T]) CStream[T] {
+	streams := make([]Stream[T], len(cs.streams))
+	for idx, s := range cs.streams {
+		streams[idx] = fn(s)
+	}
+
+	return CStream[T]{
+		streams: streams,
+	}
+}
+
+// combine creates a Stream fed with the members of this CStream by the given function,
+// and closed once the function returns. The members with a nil channel are ignored.
+// operation is the name of the method that combines the members, see Stream.Describe.
+func (cs CStream[T]) combine(operation string, feed func(members []Stream[T], outstream chan<- T)) Stream[T] {
+	members := []Stream[T]{}
+	inputs := []*PlanNode{}
+
+	for _, s := range cs.streams {
+		if s.isNil() {
+			continue
+		}
+
+		members = append(members, s.Async()) // in case its stages were fused with another stream.
+		inputs = append(inputs, s.Describe())
+	}
+
+	if len(members) == 0 {
+		return NewStreamFromSlice([]T{}, 0).planned(operation, "")
+	}
+
+	outstream := make(chan T, cap(members[0].stream))
+
+	out := derivedStream(members[0], outstream).planned(operation, "", inputs...)
+	out.releaser = nil // releasing the resulting Stream cannot release the members.
+	out.failure = newFailure()
+
+	go func() {
+		defer close(outstream)
+		out.failure.run(func() { feed(members, outstream) })
+	}()
+
+	return out
+}
@@ -0,0 +1,206 @@
+package fuego
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCStream_Concat(t *testing.T) {
+	isOdd := func(i int) bool { return i%2 == 1 }
+
+	tt := map[string]struct {
+		cstream CStream[int]
+		want    []int
+	}{
+		"Should return empty Stream when no member": {
+			cstream: NewCStream[int](),
+			want:    []int{},
+		},
+		"Should ignore members with a nil channel": {
+			cstream: NewCStream(Stream[int]{}, NewStreamFromSlice([]int{1}, 0)),
+			want:    []int{1},
+		},
+		"Should concatenate the members in order": {
+			cstream: NewCStream(
+				NewStreamFromSlice([]int{1, 2, 3}, 0),
+				NewStream(chanOf([]int{4, 5})),
+				NewStreamFromSlice([]int{6, 7, 8}, 1).Concurrent(2),
+			),
+			want: []int{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+		"Should apply Filter and Map to each member": {
+			cstream: MapCStream(
+				NewCStream(
+					NewStreamFromSlice([]int{1, 2, 3}, 0),
+					NewStreamFromSlice([]int{4, 5, 6, 7}, 0).Concurrent(3),
+				).Filter(isOdd),
+				func(i int) int { return i * 10 },
+			),
+			want: []int{10, 30, 50, 70},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := tc.cstream.Concat().ToSlice()
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCStream_Merge(t *testing.T) {
+	cs := NewCStream(
+		NewStreamFromSlice(intRange(50), 0),
+		NewStreamFromSlice([]int{100, 101, 102}, 0),
+	).Concurrent(2)
+
+	got := cs.Map(func(i int) Any { return i + 1 }).Merge()
+	assert.Equal(t, 2, got.Concurrency())
+
+	want := append(intRange(51)[1:], 101, 102, 103)
+	assert.ElementsMatch(t, want, C(got, Int).ToSlice())
+	assert.Equal(t, []int{}, NewCStream[int]().Merge().ToSlice())
+}
+
+func TestCStream_Merge_PreservesOrderOfMembers(t *testing.T) {
+	got := NewCStream(
+		NewStreamFromSlice(intRange(100), 0),
+		NewStreamFromSlice([]int{-1, -2, -3}, 0),
+	).Merge().ToSlice()
+
+	positives, negatives := []int{}, []int{}
+
+	for _, i := range got {
+		if i < 0 {
+			negatives = append(negatives, i)
+			continue
+		}
+
+		positives = append(positives, i)
+	}
+
+	assert.Equal(t, intRange(100), positives)
+	assert.Equal(t, []int{-1, -2, -3}, negatives)
+}
+
+func TestCStream_ForEach(t *testing.T) {
+	var sum int64
+
+	NewCStream(
+		NewStreamFromSlice([]int{1, 2, 3}, 0),
+		NewStreamFromSlice([]int{4, 5}, 0),
+		Stream[int]{},
+	).ForEach(func(i int) { atomic.AddInt64(&sum, int64(i)) })
+
+	assert.Equal(t, int64(15), sum)
+}
+
+func TestCStream_ForEach_IsConcurrent(t *testing.T) {
+	const members = 3
+
+	barrier := sync.WaitGroup{}
+	barrier.Add(members)
+
+	streams := make([]Stream[int], members)
+	for i := range streams {
+		streams[i] = NewStreamFromSlice([]int{i}, 0)
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		// each member waits for all the others: this would never complete
+		// if the members were not consumed concurrently.
+		NewCStream(streams...).ForEach(func(int) {
+			barrier.Done()
+			barrier.Wait()
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("members are not consumed concurrently")
+	}
+}
+
+func TestCStream_ForEach_ResumesPanicOnConsumer(t *testing.T) {
+	var consumed int64
+
+	cs := NewCStream(
+		NewStreamFromSlice(intRange(1000), 0),
+		NewStreamFromSlice(intRange(1000), 0),
+	)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		cs.ForEach(func(i int) {
+			if i == 500 {
+				panic("boom")
+			}
+
+			atomic.AddInt64(&consumed, 1)
+		})
+	})
+
+	// the remaining elements are discarded.
+	assert.Less(t, atomic.LoadInt64(&consumed), int64(1998))
+
+	for _, s := range cs.Streams() {
+		select {
+		case <-s.releaser.released():
+		default:
+			t.Error("a member was not released")
+		}
+	}
+}
+
+func TestCStream_ResumesPanicOfMembersOnConsumer(t *testing.T) {
+	boom := func(i int) int {
+		if i == 500 {
+			panic("boom")
+		}
+
+		return i
+	}
+
+	newCStream := func() CStream[int] {
+		return NewCStream(
+			NewStreamFromSlice(intRange(1000), 0),
+			Map(NewStreamFromSlice(intRange(1000), 0).Concurrent(2), boom),
+		)
+	}
+
+	assert.PanicsWithValue(t, "boom", func() { newCStream().Merge().ToSlice() })
+	assert.PanicsWithValue(t, "boom", func() { newCStream().Concat().ToSlice() })
+	assert.PanicsWithValue(t, "boom", func() { MapCStream(newCStream(), boom).Merge().ToSlice() })
+}
+
+func TestCStream_Streams(t *testing.T) {
+	cs := NewCStream(NewStreamFromSlice([]int{1}, 0), NewStreamFromSlice([]int{2}, 0))
+
+	streams := cs.Streams()
+	assert.Len(t, streams, 2)
+	assert.Equal(t, []int{1}, streams[0].ToSlice())
+	assert.Equal(t, []int{2}, streams[1].ToSlice())
+}
+
+func TestCStream_Describe(t *testing.T) {
+	got := NewCStream(NewStreamFromSlice([]int{1}, 0), NewStreamFromSlice([]int{2}, 0)).Merge()
+	defer got.ForEach(func(int) {})
+
+	assert.Equal(t, ""+
+		"-> Merge (buffer=0)\n"+
+		"    -> Async (buffer=0)\n"+
+		"        -> NewStreamFromSlice (synchronous)\n"+
+		"    -> Async (buffer=0)\n"+
+		"        -> NewStreamFromSlice (synchronous)\n",
+		got.Describe().String())
+}
@@ -18,6 +18,10 @@
 // A syntactically lighter approach is provided with `SC`` and `C``.
 // See functions `SC`` and `C `for casting Stream[Any] to a typed Stream[T any].
 //
+// Alternatively, typed functions such as `Map`, `FlatMap`, `Scan` and `Fold` accept a Stream as their
+// first argument and avoid the cost of casting. Functions `Through` and `PipeX` apply a series of typed
+// `Stage`s left-to-right, restoring a natural reading order.
+//
 // Go 1.18 suffers from a performance issue:
 //
 package fuego
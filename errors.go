@@ -1,23 +1,165 @@
 package fuego
 
+import (
+	"errors"
+	"fmt"
+)
+
+// Error is the type of the sentinel errors of fuego.
+//
+// fuego panics with these errors (or with errors that wrap them, such as DuplicateKeyError)
+// so that the recovered values can be tested with errors.Is and errors.As, e.g.:
+//
+//	if err, ok := recover().(error); ok && errors.Is(err, fuego.ErrNoSuchElement) { ... }
+//
+// The functions and methods prefixed with "Try" (e.g. TryCollect) return these errors
+// instead of panicking.
+type Error string
+
+// Error implements error.
+func (e Error) Error() string {
+	return string(e)
+}
+
+// ErrMissingChannel signifies that the Stream is missing a channel.
+const ErrMissingChannel Error = "stream requires a channel"
+
+// ErrNoSuchElement signifies that the requested element is not present.
+// Examples: when the Stream is empty, or when an Optional does not have a value.
+const ErrNoSuchElement Error = "no such element"
+
+// ErrCollectorMissingSupplier signifies that the Supplier of a Collector was not provided.
+const ErrCollectorMissingSupplier Error = "collector missing supplier"
+
+// ErrCollectorMissingAccumulator signifies that the accumulator of a Collector was not provided.
+const ErrCollectorMissingAccumulator Error = "collector missing accumulator"
+
+// ErrCollectorMissingFinisher signifies that the Finisher of a Collector was not provided.
+const ErrCollectorMissingFinisher Error = "collector missing finisher"
+
+// ErrCollectorMissingCombiner signifies that the combiner of a concurrent Collector was not provided.
+const ErrCollectorMissingCombiner Error = "collector missing combiner"
+
+// ErrNilNotPermitted signifies that the `nil` value is not allowed in the context.
+const ErrNilNotPermitted Error = "nil not permitted"
+
+// ErrDuplicateKey signifies that an attempt was made to duplicate a key in a container (such as a map).
+// See DuplicateKeyError.
+const ErrDuplicateKey Error = "duplicate key"
+
+// ErrRetriesExhausted signifies that a function failed after all the attempts permitted by its retry policy.
+// See RetriesExhaustedError.
+const ErrRetriesExhausted Error = "retries exhausted"
+
+// ErrMalformedSketch signifies that the binary encoding of a sketch (such as a TDigest) is invalid.
+const ErrMalformedSketch Error = "malformed sketch"
+
+// ErrIncompatibleSketches signifies that an attempt was made to merge sketches (such as
+// HyperLogLogs) that were not created with the same parameters.
+const ErrIncompatibleSketches Error = "incompatible sketches"
+
 // PanicMissingChannel signifies that the Stream is missing a channel.
-const PanicMissingChannel = "stream requires a channel"
+//
+// Deprecated: use ErrMissingChannel.
+const PanicMissingChannel = ErrMissingChannel
 
 // PanicNoSuchElement signifies that the requested element is not present.
 // Examples: when the Stream is empty, or when an Optional does not have a value.
-const PanicNoSuchElement = "no such element"
+//
+// Deprecated: use ErrNoSuchElement.
+const PanicNoSuchElement = ErrNoSuchElement
 
 // PanicCollectorMissingSupplier signifies that the Supplier of a Collector was not provided.
-const PanicCollectorMissingSupplier = "collector missing supplier"
+//
+// Deprecated: use ErrCollectorMissingSupplier.
+const PanicCollectorMissingSupplier = ErrCollectorMissingSupplier
 
 // PanicCollectorMissingAccumulator signifies that the accumulator of a Collector was not provided.
-const PanicCollectorMissingAccumulator = "collector missing accumulator"
+//
+// Deprecated: use ErrCollectorMissingAccumulator.
+const PanicCollectorMissingAccumulator = ErrCollectorMissingAccumulator
 
 // PanicCollectorMissingFinisher signifies that the Finisher of a Collector was not provided.
-const PanicCollectorMissingFinisher = "collector missing finisher"
+//
+// Deprecated: use ErrCollectorMissingFinisher.
+const PanicCollectorMissingFinisher = ErrCollectorMissingFinisher
 
 // PanicNilNotPermitted signifies that the `nil` value is not allowed in the context.
-const PanicNilNotPermitted = "nil not permitted"
+//
+// Deprecated: use ErrNilNotPermitted.
+const PanicNilNotPermitted = ErrNilNotPermitted
 
 // PanicDuplicateKey signifies that an attempt was made to duplicate a key in a container (such as a map).
-const PanicDuplicateKey = "duplicate key"
+//
+// Deprecated: use ErrDuplicateKey.
+const PanicDuplicateKey = ErrDuplicateKey
+
+// PanicRetriesExhausted signifies that a function failed after all the attempts permitted by its retry policy.
+//
+// Deprecated: use ErrRetriesExhausted.
+const PanicRetriesExhausted = ErrRetriesExhausted
+
+// DuplicateKeyError signifies that an attempt was made to duplicate Key in a container
+// (such as a map). It matches ErrDuplicateKey with errors.Is.
+type DuplicateKeyError struct {
+	Key any
+}
+
+// Error implements error.
+func (e DuplicateKeyError) Error() string {
+	return fmt.Sprintf("%s: '%v'", ErrDuplicateKey, e.Key)
+}
+
+// Is returns true when target is ErrDuplicateKey.
+func (e DuplicateKeyError) Is(target error) bool {
+	return target == ErrDuplicateKey
+}
+
+// RetriesExhaustedError signifies that a function failed after all the attempts permitted
+// by its retry policy. Err is the error returned by the last attempt.
+// It matches ErrRetriesExhausted with errors.Is, as well as Err.
+type RetriesExhaustedError struct {
+	Err error
+}
+
+// Error implements error.
+func (e RetriesExhaustedError) Error() string {
+	return fmt.Sprintf("%s: %v", ErrRetriesExhausted, e.Err)
+}
+
+// Is returns true when target is ErrRetriesExhausted.
+func (e RetriesExhaustedError) Is(target error) bool {
+	return target == ErrRetriesExhausted
+}
+
+// Unwrap returns the error returned by the last attempt.
+func (e RetriesExhaustedError) Unwrap() error {
+	return e.Err
+}
+
+// recoverError recovers a panic raised with a fuego error (see Error) and stores it in
+// err. Other panics are resumed. It must be deferred.
+func recoverError(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	if e, ok := r.(error); ok && isError(e) {
+		*err = e
+		return
+	}
+
+	panic(r)
+}
+
+// isError returns true when err is, or wraps, a fuego error.
+func isError(err error) bool {
+	var (
+		e  Error
+		dk DuplicateKeyError
+		re RetriesExhaustedError
+	)
+
+	return errors.As(err, &e) || errors.As(err, &dk) || errors.As(err, &re)
+}
@@ -0,0 +1,65 @@
+package fuego
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors_Is_As(t *testing.T) {
+	errTransient := errors.New("transient error")
+
+	tt := map[string]struct {
+		err     error
+		target  error
+		wantMsg string
+	}{
+		"Should match sentinel error": {
+			err:     ErrNoSuchElement,
+			target:  ErrNoSuchElement,
+			wantMsg: "no such element",
+		},
+		"Should match DuplicateKeyError with ErrDuplicateKey": {
+			err:     DuplicateKeyError{Key: "One"},
+			target:  ErrDuplicateKey,
+			wantMsg: "duplicate key: 'One'",
+		},
+		"Should match RetriesExhaustedError with ErrRetriesExhausted": {
+			err:     RetriesExhaustedError{Err: errTransient},
+			target:  ErrRetriesExhausted,
+			wantMsg: "retries exhausted: transient error",
+		},
+		"Should match RetriesExhaustedError with the error of the last attempt": {
+			err:     RetriesExhaustedError{Err: errTransient},
+			target:  errTransient,
+			wantMsg: "retries exhausted: transient error",
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, tc.err, tc.target)
+			assert.EqualError(t, tc.err, tc.wantMsg)
+		})
+	}
+
+	var dk DuplicateKeyError
+	assert.ErrorAs(t, error(DuplicateKeyError{Key: 3}), &dk)
+	assert.Equal(t, 3, dk.Key)
+	assert.NotErrorIs(t, DuplicateKeyError{Key: 3}, ErrNoSuchElement)
+}
+
+func TestRecoverError(t *testing.T) {
+	recovered := func(v any) (err error) {
+		defer recoverError(&err)
+		panic(v)
+	}
+
+	assert.Equal(t, ErrMissingChannel, recovered(ErrMissingChannel))
+	assert.Equal(t, DuplicateKeyError{Key: 1}, recovered(DuplicateKeyError{Key: 1}))
+	assert.PanicsWithValue(t, "boom", func() { _ = recovered("boom") })
+	assert.PanicsWithError(t, "other", func() { _ = recovered(errors.New("other")) })
+}
@@ -0,0 +1,86 @@
+package fuego
+
+import "sync"
+
+// failure records the first panic raised in the Go routines of the stages of a Stream
+// (such as the workers of a concurrent Map) so that it can be resumed on the Go routine
+// of the terminal operation, where it can be recovered (see TryCollect), rather than
+// crash the program. It is shared by all the Streams derived from the source.
+//
+// Once a panic is recorded, the stages stop calling the user functions and the source
+// is released (see releaser).
+type failure struct {
+	once  sync.Once
+	done  chan struct{}
+	value any
+}
+
+// newFailure creates a new failure.
+func newFailure() *failure {
+	return &failure{
+		done: make(chan struct{}),
+	}
+}
+
+// run calls fn unless a panic was already recorded, and records the panic of fn, if any.
+// On a nil failure, fn is called and its panic is not recovered.
+func (f *failure) run(fn func()) {
+	if f == nil {
+		fn()
+		return
+	}
+
+	if f.hasFailed() {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			f.once.Do(func() {
+				f.value = r
+				close(f.done)
+			})
+		}
+	}()
+
+	fn()
+}
+
+// failed returns a channel that is closed once a panic is recorded.
+// A nil failure returns a nil channel, which is never ready.
+func (f *failure) failed() <-chan struct{} {
+	if f == nil {
+		return nil
+	}
+
+	return f.done
+}
+
+// hasFailed returns true once a panic is recorded.
+func (f *failure) hasFailed() bool {
+	select {
+	case <-f.failed():
+		return true
+	default:
+		return false
+	}
+}
+
+// resume panics with the recorded panic, if any.
+func (f *failure) resume() {
+	if f.hasFailed() {
+		panic(f.value)
+	}
+}
+
+// receiveUntilFailed reads the next element of the channel of this stream.
+// It returns false when the channel is closed or once a panic is recorded.
+func (s Stream[T]) receiveUntilFailed() (T, bool) {
+	select {
+	case val, ok := <-s.stream:
+		return val, ok
+	case <-s.failure.failed():
+		var t T
+		return t, false
+	}
+}
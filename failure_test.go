@@ -0,0 +1,93 @@
+package fuego
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailure_NilResumesPanicInPlace(t *testing.T) {
+	var f *failure
+
+	assert.PanicsWithValue(t, "boom", func() { f.run(func() { panic("boom") }) })
+	assert.False(t, f.hasFailed())
+	assert.Nil(t, f.failed())
+	assert.NotPanics(t, f.resume)
+}
+
+func TestFailure_RecordsFirstPanic(t *testing.T) {
+	f := newFailure()
+	assert.NotPanics(t, f.resume)
+
+	calls := 0
+
+	f.run(func() { calls++ })
+	assert.False(t, f.hasFailed())
+
+	f.run(func() { panic("first") })
+	f.run(func() { calls++; panic("second") })
+
+	assert.Equal(t, 1, calls, "fn must not be called once a panic is recorded")
+	assert.True(t, f.hasFailed())
+	assert.PanicsWithValue(t, "first", f.resume)
+}
+
+func TestStream_receiveUntilFailed(t *testing.T) {
+	s := NewStream(make(chan int))
+	s.failure.run(func() { panic("boom") })
+
+	_, ok := s.receiveUntilFailed()
+	assert.False(t, ok)
+}
+
+func TestStream_ConcurrentStagesResumePanicOnConsumer(t *testing.T) {
+	boom := func(i int) int {
+		if i == 500 {
+			panic("boom")
+		}
+
+		return i
+	}
+
+	tt := map[string]func(s Stream[int]) Stream[int]{
+		"Map": func(s Stream[int]) Stream[int] {
+			return Map(s, boom)
+		},
+		"MapUnordered": func(s Stream[int]) Stream[int] {
+			return C(s.MapUnordered(func(i int) Any { return boom(i) }), Int)
+		},
+		"FlatMapUnordered": func(s Stream[int]) Stream[int] {
+			return C(s.FlatMapUnordered(func(i int) Stream[Any] {
+				return NewStreamFromSlice([]Any{boom(i)}, 0)
+			}), Int)
+		},
+		"MapByKey": func(s Stream[int]) Stream[int] {
+			return C(s.MapByKey(func(i int) Any { return i % 7 }, 3, func(i int) Any { return boom(i) }), Int)
+		},
+		"MapByKey with panicking key function": func(s Stream[int]) Stream[int] {
+			return C(s.MapByKey(func(i int) Any { return boom(i) }, 3, func(i int) Any { return i }), Int)
+		},
+		"Filter": func(s Stream[int]) Stream[int] {
+			return s.Filter(func(i int) bool { return boom(i) >= 0 })
+		},
+		"Map then Filter": func(s Stream[int]) Stream[int] {
+			return Map(s, boom).Filter(True[int]())
+		},
+	}
+
+	for name, stage := range tt {
+		stage := stage
+
+		t.Run(name, func(t *testing.T) {
+			source := NewStreamFromSlice(intRange(1000), 0).Concurrent(4)
+
+			assert.PanicsWithValue(t, "boom", func() { stage(source).ToSlice() })
+
+			select {
+			case <-source.releaser.released():
+			default:
+				t.Error("the source was not released")
+			}
+		})
+	}
+}
@@ -6,6 +6,9 @@ type Function[T, R any] func(T) R
 // BiFunction that accepts two arguments and produces a result.
 type BiFunction[T, U, R any] func(T, U) R
 
+// ErrorFunction that accepts one argument and produces a result or an error.
+type ErrorFunction[T, R any] func(T) (R, error)
+
 // BinaryOperator that accepts two arguments of the same type and produces a result of the same type.
 // This is a special case of BiFunction.
 type BinaryOperator[T any] func(T, T) T
@@ -0,0 +1,175 @@
+package fuego
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// NOTICE:
+// Fusion of adjacent stateless stages.
+//
+// Non-concurrent Filter, Map, Peek, TakeWhile and DropWhile stages (and their variants
+// such as Take and Drop) of a Stream backed by a channel are fused: rather than each
+// stage running in its own Go routine connected to the next one by a channel, a chain
+// of such stages runs as function calls in a single Go routine.
+//
+// The chain is built as the stages are created: each stage starts a Go routine and,
+// when the next stage of the chain is created, it takes over the stage functions of
+// that Go routine, which then stops. The elements already sent by the stopped Go routine
+// are read first so that order is preserved and no element is lost.
+//
+// The Stream whose Go routine was taken over remains usable: its elements are then read
+// from the chain of stages (see fusedStage.next) rather than from its channel, which is
+// closed. The chain is shared, under a lock, by all the Streams that consume it: as with
+// a channel, each element is delivered to only one of them.
+//
+// The stages of synchronous streams (see pull.go) are not concerned since they do not
+// use Go routines at all.
+
+// DisableFusion disables the fusion of stages, both for channel-backed streams (see
+// above) and for synchronous streams (see Stream.Async): every stage then runs in its
+// own Go routine. This is intended to aid troubleshooting.
+//
+// It is initialised from environment variable FUEGO_DISABLE_FUSION (any non-empty value
+// disables fusion). It is read when the stages are created and hence should not be
+// modified while streams are being created.
+var DisableFusion = os.Getenv("FUEGO_DISABLE_FUSION") != "" // nolint: gochecknoglobals
+
+// fusedStage is a chain of fused stages running in a single Go routine.
+type fusedStage[T any] struct {
+	pull     func() (T, bool)
+	out      chan T
+	hooks    *stageHooks
+	takeover chan struct{}
+	once     sync.Once
+	done     chan struct{}
+	pending  *T
+	lock     sync.Mutex
+	stopped  bool
+}
+
+// fuse returns a Stream that applies the stage to the elements of Stream s.
+// stage composes the pull function of the preceding stages with its own.
+//
+// When s is synchronous, so is the returned Stream. Otherwise, the stage is fused
+// with the preceding fused stages, if any, in a single Go routine.
+//
+// h, which may be nil, is notified of the activity of the stage.
+func fuse[T, U any](s Stream[T], h *stageHooks, stage func(func() (T, bool)) func() (U, bool)) Stream[U] {
+	if DisableFusion {
+		s = s.Async()
+		return newFusedStream(s, h, instrumentPull(h, s.receive, stage))
+	}
+
+	if s.pull != nil {
+		return derivedPullStream(s, instrumentPull(h, s.pull, stage))
+	}
+
+	if s.fused != nil {
+		// s.receive now reads the chain of stages of s in the Go routine of the new stage.
+		s.fused.takeOver()
+	}
+
+	return newFusedStream(s, h, instrumentPull(h, s.receive, stage))
+}
+
+// newFusedStream creates a new Stream that inherits the settings of Stream s and
+// whose elements are produced by the pull function in a new Go routine.
+// h, which may be nil, is notified of the elements sent to the channel of the Stream.
+func newFusedStream[T, U any](s Stream[T], h *stageHooks, pull func() (U, bool)) Stream[U] {
+	f := &fusedStage[U]{
+		pull:     pull,
+		out:      make(chan U, cap(s.stream)),
+		hooks:    h,
+		takeover: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	go f.run()
+
+	fs := derivedStream(s, f.out)
+	fs.fused = f
+
+	return fs
+}
+
+// run sends the elements produced by the chain of stages to the out channel
+// until the chain is exhausted or taken over.
+func (f *fusedStage[T]) run() {
+	defer close(f.done)
+	defer close(f.out)
+
+	for {
+		select {
+		case <-f.takeover:
+			return
+		default:
+		}
+
+		val, ok := f.pull()
+		if !ok {
+			return
+		}
+
+		var start time.Time
+		if f.hooks != nil {
+			start = time.Now()
+		}
+
+		length := len(f.out)
+
+		select {
+		case f.out <- val:
+			f.hooks.sent(length, cap(f.out), start)
+		case <-f.takeover:
+			f.pending = &val
+			return
+		}
+	}
+}
+
+// takeOver stops the Go routine of this chain of stages, whose elements are then
+// produced by next.
+func (f *fusedStage[T]) takeOver() {
+	f.once.Do(func() {
+		close(f.takeover)
+	})
+}
+
+// isTakenOver returns true when the Go routine of this chain of stages has been taken over.
+func (f *fusedStage[T]) isTakenOver() bool {
+	select {
+	case <-f.takeover:
+		return true
+	default:
+		return false
+	}
+}
+
+// next returns the next element of this chain of stages once it has been taken over:
+// the elements sent by the stopped Go routine first, then those produced by the stages.
+// It returns false when the chain is exhausted.
+func (f *fusedStage[T]) next() (T, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if !f.stopped {
+		<-f.done
+
+		if val, ok := <-f.out; ok {
+			return val, true
+		}
+
+		f.stopped = true
+
+		if f.pending != nil {
+			val := *f.pending
+			f.pending = nil
+
+			return val, true
+		}
+	}
+
+	return f.pull()
+}
@@ -0,0 +1,224 @@
+package fuego
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// chanOf returns a closed channel that holds the elements of the slice.
+func chanOf[T any](slice []T) chan T {
+	c := make(chan T, len(slice))
+	for _, val := range slice {
+		c <- val
+	}
+	close(c)
+
+	return c
+}
+
+// isTakenOver returns true when the chain of stages of Stream s has been taken over by a downstream stage.
+func isTakenOver[T any](s Stream[T]) bool {
+	return s.fused.isTakenOver()
+}
+
+func TestFuse(t *testing.T) {
+	if DisableFusion {
+		t.Skip("fusion is disabled")
+	}
+
+	peeked := []int{}
+
+	filtered := NewStream(chanOf([]int{1, 2, 3, 4, 5, 6, 7, 8})).
+		Filter(func(i int) bool { return i%2 == 0 })
+
+	dropped := filtered.
+		Peek(func(i int) { peeked = append(peeked, i) }).
+		DropWhile(func(i int) bool { return i < 4 })
+
+	got := Map(dropped, strconv.Itoa)
+
+	assert.True(t, isTakenOver(filtered))
+	assert.True(t, isTakenOver(dropped))
+	assert.False(t, isTakenOver(got))
+	assert.Equal(t, []string{"4", "6", "8"}, got.ToSlice())
+	assert.Equal(t, []int{2, 4, 6, 8}, peeked)
+}
+
+func TestFuse_PreservesOrderOfElementsInTransit(t *testing.T) {
+	if DisableFusion {
+		t.Skip("fusion is disabled")
+	}
+
+	filtered := NewStream(chanOf(intRange(10))).Filter(True[int]())
+
+	// let the Go routine of the Filter stage send some elements before it is taken over.
+	for len(filtered.stream) < 5 {
+		time.Sleep(time.Millisecond)
+	}
+
+	got := Map(filtered, func(i int) int { return i * 10 }).ToSlice()
+	assert.Equal(t, []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}, got)
+}
+
+func TestFuse_StreamRemainsUsableAfterTakeOver(t *testing.T) {
+	s := NewStream(chanOf(intRange(10))).Filter(True[int]())
+
+	assert.Equal(t, []int{0, 1}, s.HeadN(2))
+	// Take reads (and drops) the element that follows the last one it takes.
+	assert.Equal(t, []int{3, 4, 5, 6, 7, 8, 9}, s.ToSlice())
+}
+
+func TestFuse_StreamsShareTakenOverStages(t *testing.T) {
+	if DisableFusion {
+		t.Skip("fusion is disabled")
+	}
+
+	const numEntries = 1000
+
+	s := NewStream(chanOf(intRange(numEntries))).Filter(True[int]())
+	mapped := Map(s, func(i int) int { return i + numEntries })
+	filtered := s.Filter(True[int]()) // s is already taken over by mapped.
+
+	assert.True(t, isTakenOver(s))
+
+	got := make([][]int, 3)
+	wg := sync.WaitGroup{}
+	wg.Add(len(got))
+
+	for idx, stream := range []Stream[int]{s, mapped, filtered} {
+		go func(idx int, stream Stream[int]) {
+			defer wg.Done()
+			got[idx] = stream.ToSlice()
+		}(idx, stream)
+	}
+
+	wg.Wait()
+
+	// each element is delivered to only one of the streams, in order.
+	all := []int{}
+	for idx, vals := range got {
+		assert.True(t, sort.IntsAreSorted(vals), "stream #%d", idx)
+
+		for _, val := range vals {
+			all = append(all, val%numEntries)
+		}
+	}
+
+	assert.ElementsMatch(t, intRange(numEntries), all)
+}
+
+func TestFuse_CastStreamRemainsUsableAfterTakeOver(t *testing.T) {
+	if DisableFusion {
+		t.Skip("fusion is disabled")
+	}
+
+	const numEntries = 10
+
+	c := make(chan Any)
+	go func() {
+		defer close(c)
+
+		for i := 0; i < numEntries; i++ {
+			c <- i
+		}
+	}()
+
+	m := NewStream(c).Map(func(a Any) Any { return a.(int) * 10 })
+	other := m.Filter(True[Any]()) // m is taken over by other.
+
+	assert.True(t, isTakenOver(m))
+
+	// other holds at most one element, in its unbuffered channel.
+	got := C(m, Int).ToSlice()
+	assert.GreaterOrEqual(t, len(got), numEntries-1)
+	assert.True(t, sort.IntsAreSorted(got))
+
+	assert.Len(t, other.ToSlice(), numEntries-len(got))
+}
+
+func TestFuse_TakeWhileShortCircuits(t *testing.T) {
+	if DisableFusion {
+		t.Skip("fusion is disabled")
+	}
+
+	c := make(chan int)
+	stop := make(chan struct{})
+	sent := make(chan int, 1)
+
+	go func() {
+		count := 0
+		defer func() { sent <- count }()
+
+		for i := 1; i <= 5; i++ {
+			select {
+			case c <- i:
+				count++
+			case <-stop:
+				return
+			}
+		}
+		close(c)
+	}()
+
+	mapped := []int{}
+
+	s := NewStream(c).
+		Filter(True[int]()).
+		TakeWhile(func(i int) bool { return i < 3 })
+
+	got := Map(s, func(i int) int {
+		mapped = append(mapped, i)
+		return i
+	}).ToSlice()
+
+	close(stop)
+
+	assert.Equal(t, []int{1, 2}, got)
+	assert.Equal(t, []int{1, 2}, mapped)
+	assert.Equal(t, 3, <-sent)
+}
+
+func TestFuse_Disabled(t *testing.T) {
+	defer func(disabled bool) { DisableFusion = disabled }(DisableFusion)
+	DisableFusion = true
+
+	filtered := NewStream(chanOf([]int{1, 2, 3, 4})).Filter(func(i int) bool { return i > 1 })
+	got := Map(filtered, func(i int) int { return i * 10 })
+
+	assert.False(t, isTakenOver(filtered))
+	assert.Equal(t, []int{20, 30, 40}, got.ToSlice())
+
+	s := NewStreamFromSlice([]int{1, 2, 3}, 0).Filter(True[int]())
+	assert.Nil(t, s.pull)
+	assert.Equal(t, []int{1, 2, 3}, s.ToSlice())
+}
+
+func BenchmarkFuse(b *testing.B) {
+	data := intRange(1000)
+	isEven := func(i int) bool { return i%2 == 0 }
+	double := func(i int) int { return i * 2 }
+
+	run := func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s := NewStream(chanOf(data)).
+				Filter(isEven).
+				Peek(func(int) {}).
+				DropWhile(func(i int) bool { return i < 10 })
+			_ = Map(s, double).ToSlice()
+		}
+	}
+
+	b.Run("fused", run)
+
+	b.Run("not fused", func(b *testing.B) {
+		defer func(disabled bool) { DisableFusion = disabled }(DisableFusion)
+		DisableFusion = true
+
+		run(b)
+	})
+}
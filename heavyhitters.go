@@ -0,0 +1,283 @@
+package fuego
+
+import (
+	"container/heap"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+// maxCountMinWidth and maxCountMinDepth cap the dimensions of the Count-Min sketch of a
+// HeavyHittersSketch, and so its memory to 8 MiB, whatever the epsilon and delta (an
+// epsilon of about 4e-5 and a delta of about 1e-7).
+const (
+	maxCountMinWidth = 1 << 16
+	maxCountMinDepth = 16
+)
+
+// HeavyHitter is an element of a stream and its (estimated) number of occurrences.
+type HeavyHitter[T any] struct {
+	Element T
+	Count   uint64
+}
+
+// HeavyHittersSketch is a sketch that finds the k most frequent elements of a (possibly
+// very large) stream in bounded memory. It combines a Count-Min sketch, which estimates
+// the number of occurrences of any element, with the k elements of highest estimate.
+//
+// For a total of N elements, with an error epsilon and a probability of failure delta
+// (see HeavyHitters):
+//   - the estimated count of an element is never below its exact count and, with a
+//     probability of at least 1 - delta, exceeds it by at most epsilon * N.
+//   - every element that makes more than (1/k + epsilon) * N occurrences is reported,
+//     with a probability of at least 1 - delta.
+//
+// The Count-Min sketch has ⌈e / epsilon⌉ x ⌈ln(1 / delta)⌉ counters of 8 bytes, capped
+// at 2^16 x 16.
+//
+// Sketches created with the same parameters can be merged (see Merge): the result
+// finds the most frequent elements of the concatenation of their streams.
+//
+// A HeavyHittersSketch is not safe for concurrent use.
+type HeavyHittersSketch[T comparable] struct {
+	k        int
+	width    int
+	depth    int
+	counters []uint64 // depth rows of width counters.
+	total    uint64
+	top      heavyHitterHeap[T]
+}
+
+// NewHeavyHittersSketch creates an empty HeavyHittersSketch that finds the k most frequent
+// elements, with the given error and probability of failure (see HeavyHittersSketch).
+// Values of k below 0 are treated as 0, and epsilon and delta should be between 0 and 1.
+func NewHeavyHittersSketch[T comparable](k int, epsilon, delta float64) *HeavyHittersSketch[T] {
+	if k < 0 {
+		k = 0
+	}
+
+	width := clampCeil(math.E/epsilon, maxCountMinWidth)
+	depth := clampCeil(math.Log(1/delta), maxCountMinDepth)
+
+	return &HeavyHittersSketch[T]{
+		k:        k,
+		width:    width,
+		depth:    depth,
+		counters: make([]uint64, width*depth),
+		top: heavyHitterHeap[T]{
+			index: map[T]int{},
+		},
+	}
+}
+
+// Add adds an occurrence of the element to this HeavyHittersSketch.
+func (s *HeavyHittersSketch[T]) Add(val T) {
+	estimate := uint64(math.MaxUint64)
+	h1, h2 := s.hashes(val)
+
+	for row := 0; row < s.depth; row++ {
+		idx := row*s.width + s.column(h1, h2, row)
+		s.counters[idx]++
+
+		if s.counters[idx] < estimate {
+			estimate = s.counters[idx]
+		}
+	}
+
+	s.total++
+	s.offer(val, estimate)
+}
+
+// Estimate returns the estimated number of occurrences of the element.
+func (s *HeavyHittersSketch[T]) Estimate(val T) uint64 {
+	estimate := uint64(math.MaxUint64)
+	h1, h2 := s.hashes(val)
+
+	for row := 0; row < s.depth; row++ {
+		if c := s.counters[row*s.width+s.column(h1, h2, row)]; c < estimate {
+			estimate = c
+		}
+	}
+
+	return estimate
+}
+
+// Count returns the number of elements added to this HeavyHittersSketch.
+func (s *HeavyHittersSketch[T]) Count() uint64 {
+	return s.total
+}
+
+// Top returns (at most) k elements of highest estimated count, in descending order of count.
+func (s *HeavyHittersSketch[T]) Top() []HeavyHitter[T] {
+	top := make([]HeavyHitter[T], len(s.top.hitters))
+	for idx, hitter := range s.top.hitters {
+		top[idx] = HeavyHitter[T]{Element: hitter.Element, Count: s.Estimate(hitter.Element)}
+	}
+
+	sort.SliceStable(top, func(i, j int) bool { return top[i].Count > top[j].Count })
+
+	return top
+}
+
+// Merge adds the elements of the other HeavyHittersSketch to this HeavyHittersSketch.
+// The other HeavyHittersSketch is left unchanged.
+// It returns an error that matches ErrIncompatibleSketches when the sketches were not
+// created with the same parameters.
+func (s *HeavyHittersSketch[T]) Merge(other *HeavyHittersSketch[T]) error {
+	if other == nil {
+		return nil
+	}
+
+	if other.k != s.k || other.width != s.width || other.depth != s.depth {
+		return fmt.Errorf("%w: HeavyHittersSketch (k, width, depth) (%d, %d, %d) and (%d, %d, %d)",
+			ErrIncompatibleSketches, s.k, s.width, s.depth, other.k, other.width, other.depth)
+	}
+
+	for idx, c := range other.counters {
+		s.counters[idx] += c
+	}
+
+	s.total += other.total
+
+	// the candidates are the top elements of both sketches, with their merged estimates.
+	candidates := append(append([]HeavyHitter[T]{}, s.top.hitters...), other.top.hitters...)
+	s.top = heavyHitterHeap[T]{
+		index: map[T]int{},
+	}
+
+	for _, c := range candidates {
+		s.offer(c.Element, s.Estimate(c.Element))
+	}
+
+	return nil
+}
+
+// offer records the estimated count of the element in the top elements, evicting the
+// element of lowest count when the top elements are full.
+func (s *HeavyHittersSketch[T]) offer(val T, estimate uint64) {
+	if idx, ok := s.top.index[val]; ok {
+		s.top.hitters[idx].Count = estimate
+		heap.Fix(&s.top, idx)
+
+		return
+	}
+
+	if len(s.top.hitters) < s.k {
+		heap.Push(&s.top, HeavyHitter[T]{Element: val, Count: estimate})
+		return
+	}
+
+	if s.k > 0 && estimate > s.top.hitters[0].Count {
+		delete(s.top.index, s.top.hitters[0].Element)
+		s.top.hitters[0] = HeavyHitter[T]{Element: val, Count: estimate}
+		s.top.index[val] = 0
+		heap.Fix(&s.top, 0)
+	}
+}
+
+// hashes returns the two hashes of the element from which the hash functions of the rows
+// of the Count-Min sketch are derived, by double hashing (see column).
+func (s *HeavyHittersSketch[T]) hashes(val T) (uint64, uint64) {
+	h1 := mix64(hashKey(val))
+	return h1, mix64(h1) | 1
+}
+
+// column returns the column of the element of hashes h1 and h2 in the given row of the
+// Count-Min sketch.
+func (s *HeavyHittersSketch[T]) column(h1, h2 uint64, row int) int {
+	return int((h1 + uint64(row)*h2) % uint64(s.width))
+}
+
+// heavyHitterHeap is a min-heap of HeavyHitters by count (see container/heap), indexed by element.
+type heavyHitterHeap[T comparable] struct {
+	hitters []HeavyHitter[T]
+	index   map[T]int // position of the elements in hitters.
+}
+
+func (h heavyHitterHeap[T]) Len() int { return len(h.hitters) }
+
+func (h heavyHitterHeap[T]) Less(i, j int) bool { return h.hitters[i].Count < h.hitters[j].Count }
+
+func (h heavyHitterHeap[T]) Swap(i, j int) {
+	h.hitters[i], h.hitters[j] = h.hitters[j], h.hitters[i]
+	h.index[h.hitters[i].Element] = i
+	h.index[h.hitters[j].Element] = j
+}
+
+func (h *heavyHitterHeap[T]) Push(x any) {
+	hitter := x.(HeavyHitter[T]) // nolint: forcetypeassert
+	h.index[hitter.Element] = len(h.hitters)
+	h.hitters = append(h.hitters, hitter)
+}
+
+func (h *heavyHitterHeap[T]) Pop() any {
+	hitter := h.hitters[len(h.hitters)-1]
+	h.hitters = h.hitters[:len(h.hitters)-1]
+	delete(h.index, hitter.Element)
+
+	return hitter
+}
+
+// clampCeil returns ⌈x⌉ within the range [1, max]. NaN is treated as max.
+func clampCeil(x float64, max int) int {
+	switch {
+	case !(x < float64(max)): // also catches NaN.
+		return max
+	case x < 1:
+		return 1
+	default:
+		return int(math.Ceil(x))
+	}
+}
+
+// hashKey returns a 64-bit hash of the element.
+func hashKey[T comparable](val T) uint64 {
+	switch v := any(val).(type) {
+	case int:
+		return uint64(v)
+	case int64:
+		return uint64(v)
+	case uint64:
+		return v
+	case string:
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(v))
+
+		return h.Sum64()
+	}
+
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%#v", val)
+
+	return h.Sum64()
+}
+
+// HeavyHitters returns a concurrent collector that produces a HeavyHittersSketch of the
+// input elements, which finds the k most frequent elements in bounded memory with the
+// given error and probability of failure (see HeavyHittersSketch).
+//
+// Example: the 10 most frequent search terms, with an error of at most 0.1% of the
+// number of searches in 99% of the cases:
+//
+//	top := Collect(searchTerms, HeavyHitters[string](10, 0.001, 0.01)).Top()
+func HeavyHitters[T comparable](k int, epsilon, delta float64) Collector[T, *HeavyHittersSketch[T], *HeavyHittersSketch[T]] {
+	supplier := func() *HeavyHittersSketch[T] {
+		return NewHeavyHittersSketch[T](k, epsilon, delta)
+	}
+
+	accumulator := func(s *HeavyHittersSketch[T], val T) *HeavyHittersSketch[T] {
+		s.Add(val)
+		return s
+	}
+
+	combiner := func(s1, s2 *HeavyHittersSketch[T]) *HeavyHittersSketch[T] {
+		if err := s1.Merge(s2); err != nil {
+			panic(err)
+		}
+
+		return s1
+	}
+
+	return NewConcurrentCollector(supplier, accumulator, combiner, IdentityFinisher[*HeavyHittersSketch[T]])
+}
@@ -0,0 +1,240 @@
+package fuego
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// zipfStream returns n elements drawn from a Zipf distribution, and their exact counts.
+func zipfStream(rnd *rand.Rand, n int) ([]uint64, map[uint64]uint64) {
+	zipf := rand.NewZipf(rnd, 1.2, 1, 100_000)
+
+	values := make([]uint64, n)
+	counts := map[uint64]uint64{}
+
+	for idx := range values {
+		values[idx] = zipf.Uint64()
+		counts[values[idx]]++
+	}
+
+	return values, counts
+}
+
+// assertHeavyHitters asserts the error bounds documented in HeavyHittersSketch.
+func assertHeavyHitters(t *testing.T, s *HeavyHittersSketch[uint64], k int, epsilon float64, counts map[uint64]uint64) {
+	t.Helper()
+
+	n := float64(s.Count())
+	maxError := uint64(epsilon * n)
+
+	top := s.Top()
+	require.Len(t, top, k)
+
+	reported := map[uint64]bool{}
+
+	for idx, hitter := range top {
+		reported[hitter.Element] = true
+
+		assert.GreaterOrEqual(t, hitter.Count, counts[hitter.Element])
+		assert.LessOrEqual(t, hitter.Count, counts[hitter.Element]+maxError)
+
+		if idx > 0 {
+			assert.LessOrEqual(t, hitter.Count, top[idx-1].Count)
+		}
+	}
+
+	for element, count := range counts {
+		if float64(count) > (1/float64(k)+epsilon)*n {
+			assert.True(t, reported[element], "heavy hitter %d (count %d) is missing", element, count)
+		}
+	}
+}
+
+func TestHeavyHittersSketch(t *testing.T) {
+	const (
+		k       = 10
+		epsilon = 0.001
+		delta   = 0.01
+	)
+
+	values, counts := zipfStream(seededRand(), 200_000)
+
+	s := NewHeavyHittersSketch[uint64](k, epsilon, delta)
+	for _, v := range values {
+		s.Add(v)
+	}
+
+	assert.Equal(t, uint64(len(values)), s.Count())
+	assertHeavyHitters(t, s, k, epsilon, counts)
+
+	// with a probability of at least 1 - delta, the estimates are within epsilon * N.
+	failures := 0
+
+	for element, count := range counts {
+		estimate := s.Estimate(element)
+		assert.GreaterOrEqual(t, estimate, count)
+
+		if estimate > count+uint64(epsilon*float64(len(values))) {
+			failures++
+		}
+	}
+
+	assert.LessOrEqual(t, float64(failures), delta*float64(len(counts)))
+}
+
+func TestNewHeavyHittersSketch(t *testing.T) {
+	tt := map[string]struct {
+		k              int
+		epsilon, delta float64
+		wantK          int
+		wantWidth      int
+		wantDepth      int
+	}{
+		"Should size the sketch": {
+			k:         5,
+			epsilon:   0.01,
+			delta:     0.01,
+			wantK:     5,
+			wantWidth: 272,
+			wantDepth: 5,
+		},
+		"Should treat k < 0 as 0": {
+			k:         -1,
+			epsilon:   0.5,
+			delta:     0.5,
+			wantWidth: 6,
+			wantDepth: 1,
+		},
+		"Should cap the sketch": {
+			k:         1,
+			epsilon:   0,
+			delta:     0,
+			wantK:     1,
+			wantWidth: 1 << 16,
+			wantDepth: 16,
+		},
+		"Should ensure a minimal sketch": {
+			k:         1,
+			epsilon:   5,
+			delta:     1,
+			wantK:     1,
+			wantWidth: 1,
+			wantDepth: 1,
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			s := NewHeavyHittersSketch[string](tc.k, tc.epsilon, tc.delta)
+			assert.Equal(t, tc.wantK, s.k)
+			assert.Equal(t, tc.wantWidth, s.width)
+			assert.Equal(t, tc.wantDepth, s.depth)
+			assert.Len(t, s.counters, tc.wantWidth*tc.wantDepth)
+		})
+	}
+}
+
+func TestHeavyHittersSketch_SmallInputs(t *testing.T) {
+	s := NewHeavyHittersSketch[string](2, 0.01, 0.01)
+	assert.Empty(t, s.Top())
+	assert.Equal(t, uint64(0), s.Estimate("a"))
+
+	for _, v := range []string{"a", "b", "a", "c", "c", "c"} {
+		s.Add(v)
+	}
+
+	assert.Equal(t, []HeavyHitter[string]{{Element: "c", Count: 3}, {Element: "a", Count: 2}}, s.Top())
+
+	none := NewHeavyHittersSketch[string](0, 0.01, 0.01)
+	none.Add("a")
+	assert.Empty(t, none.Top())
+	assert.Equal(t, uint64(1), none.Estimate("a"))
+}
+
+func TestHeavyHittersSketch_Merge(t *testing.T) {
+	const (
+		k       = 10
+		epsilon = 0.001
+		delta   = 0.01
+	)
+
+	values, counts := zipfStream(seededRand(), 200_000)
+
+	shards := make([]*HeavyHittersSketch[uint64], 4)
+	for idx := range shards {
+		shards[idx] = NewHeavyHittersSketch[uint64](k, epsilon, delta)
+	}
+
+	for idx, v := range values {
+		shards[idx*len(shards)/len(values)].Add(v)
+	}
+
+	shard0 := shards[0].Top()
+
+	got := NewHeavyHittersSketch[uint64](k, epsilon, delta)
+	for _, shard := range shards {
+		require.NoError(t, got.Merge(shard))
+	}
+
+	require.NoError(t, got.Merge(nil))
+
+	assert.Equal(t, uint64(len(values)), got.Count())
+	assertHeavyHitters(t, got, k, epsilon, counts)
+	assert.Equal(t, shard0, shards[0].Top(), "the merged sketches must be left unchanged")
+
+	for _, other := range []*HeavyHittersSketch[uint64]{
+		NewHeavyHittersSketch[uint64](k+1, epsilon, delta),
+		NewHeavyHittersSketch[uint64](k, epsilon*2, delta),
+		NewHeavyHittersSketch[uint64](k, epsilon, delta/100),
+	} {
+		err := got.Merge(other)
+		assert.True(t, errors.Is(err, ErrIncompatibleSketches), err)
+	}
+}
+
+type searchTerm struct {
+	term string
+	lang string
+}
+
+func TestCollector_HeavyHitters(t *testing.T) {
+	values := []searchTerm{}
+	for i := 0; i < 1_000; i++ {
+		values = append(values,
+			searchTerm{term: "fuego", lang: "es"},
+			searchTerm{term: "stream", lang: "en"},
+			searchTerm{term: "fuego", lang: "es"},
+			searchTerm{term: string(rune('a' + i%26)), lang: "en"},
+		)
+
+		if i%2 == 0 {
+			values = append(values, searchTerm{term: "fuego", lang: "it"})
+		}
+	}
+
+	tt := map[string]Stream[searchTerm]{
+		"sequential": NewStreamFromSlice(values, 0),
+		"concurrent": NewStreamFromSlice(values, 10).Concurrent(4),
+	}
+
+	for name, stream := range tt {
+		stream := stream
+
+		t.Run(name, func(t *testing.T) {
+			c := HeavyHitters[searchTerm](2, 0.001, 0.01)
+			assert.True(t, c.IsConcurrent())
+
+			got := Collect(stream, c).Top()
+			require.Len(t, got, 2)
+			assert.Equal(t, HeavyHitter[searchTerm]{Element: searchTerm{term: "fuego", lang: "es"}, Count: 2_000}, got[0])
+			assert.Equal(t, searchTerm{term: "stream", lang: "en"}, got[1].Element)
+			assert.InDelta(t, 1_000, got[1].Count, 5)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package fuego
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// MinHyperLogLogPrecision and MaxHyperLogLogPrecision are the bounds of the precision of
+// a HyperLogLog.
+const (
+	MinHyperLogLogPrecision = 4
+	MaxHyperLogLogPrecision = 18
+)
+
+// HyperLogLog is a sketch that estimates the number of distinct elements of a (possibly
+// very large) set in constant memory, using the HyperLogLog algorithm of Flajolet et al.
+//
+// A HyperLogLog of precision p uses m = 2^p registers of one byte. The relative standard
+// error of the estimate is 1.04 / √m: for instance 1.6% for p = 12 (4 KiB) and 0.81% for
+// p = 14 (16 KiB). The estimate is within 3 standard errors of the exact count in more
+// than 99% of the cases. Small cardinalities (up to 2.5m) are estimated with linear
+// counting, which is more accurate.
+//
+// HyperLogLogs of the same precision can be merged (see Merge): the result estimates
+// the number of distinct elements of the union of their sets.
+//
+// A HyperLogLog is not safe for concurrent use.
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+}
+
+// NewHyperLogLog creates an empty HyperLogLog with the given precision.
+// Values of precision outside the range [MinHyperLogLogPrecision, MaxHyperLogLogPrecision]
+// are clamped.
+func NewHyperLogLog(precision int) *HyperLogLog {
+	if precision < MinHyperLogLogPrecision {
+		precision = MinHyperLogLogPrecision
+	}
+
+	if precision > MaxHyperLogLogPrecision {
+		precision = MaxHyperLogLogPrecision
+	}
+
+	return &HyperLogLog{
+		precision: uint8(precision),
+		registers: make([]uint8, 1<<precision),
+	}
+}
+
+// Precision returns the precision of this HyperLogLog.
+func (h *HyperLogLog) Precision() int {
+	return int(h.precision)
+}
+
+// StandardError returns the relative standard error of the estimates of this HyperLogLog.
+func (h *HyperLogLog) StandardError() float64 {
+	return 1.04 / math.Sqrt(float64(len(h.registers)))
+}
+
+// AddHash adds the element with the given 64-bit hash to this HyperLogLog.
+//
+// The hash is mixed before use, so that a hash function with a poor distribution of
+// its bits (such as FNV-1a) is adequate. However, distinct elements whose hashes
+// collide are counted once.
+func (h *HyperLogLog) AddHash(hash uint64) {
+	hash = mix64(hash)
+
+	idx := hash >> (64 - h.precision)
+	// the number of leading zeros of the remaining bits, plus one. The sentinel bit
+	// caps the result for when the remaining bits are all zeros.
+	rank := uint8(bits.LeadingZeros64(hash<<h.precision|1<<(h.precision-1))) + 1
+
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+// Count returns the estimated number of distinct elements added to this HyperLogLog.
+func (h *HyperLogLog) Count() uint64 {
+	m := float64(len(h.registers))
+
+	sum, zeros := 0.0, 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	estimate := hllAlpha(len(h.registers)) * m * m / sum
+
+	if estimate <= 2.5*m && zeros > 0 {
+		// linear counting.
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(math.Round(estimate))
+}
+
+// Merge adds the elements of the other HyperLogLog to this HyperLogLog.
+// The other HyperLogLog is left unchanged.
+// It returns an error that matches ErrIncompatibleSketches when the precisions differ.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if other == nil {
+		return nil
+	}
+
+	if other.precision != h.precision {
+		return fmt.Errorf("%w: HyperLogLog precisions %d and %d", ErrIncompatibleSketches, h.precision, other.precision)
+	}
+
+	for idx, r := range other.registers {
+		if r > h.registers[idx] {
+			h.registers[idx] = r
+		}
+	}
+
+	return nil
+}
+
+// hllAlpha returns the bias correction constant of a HyperLogLog of m registers.
+func hllAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
+
+// mix64 is the finaliser of SplitMix64: it spreads the entropy of the bits of x across
+// all the bits of the result.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
+
+// ApproxCountDistinct returns a concurrent collector that produces a HyperLogLog of the
+// input elements with the given precision (see NewHyperLogLog). The HyperLogLog estimates
+// the number of distinct elements in constant memory.
+//
+// hashFn must return the same hash for equal elements and should return distinct hashes
+// for distinct elements (e.g. FNV-1a from package hash/fnv). It must be safe for
+// concurrent use.
+//
+// Example:
+//
+//	hll := Collect(userIDs, ApproxCountDistinct(hashUserID, 14))
+//	distinctUsers := hll.Count() // ± 0.81%
+func ApproxCountDistinct[T any](hashFn Function[T, uint64], precision int) Collector[T, *HyperLogLog, *HyperLogLog] {
+	supplier := func() *HyperLogLog {
+		return NewHyperLogLog(precision)
+	}
+
+	accumulator := func(h *HyperLogLog, val T) *HyperLogLog {
+		h.AddHash(hashFn(val))
+		return h
+	}
+
+	combiner := func(h1, h2 *HyperLogLog) *HyperLogLog {
+		if err := h1.Merge(h2); err != nil {
+			panic(err)
+		}
+
+		return h1
+	}
+
+	return NewConcurrentCollector(supplier, accumulator, combiner, IdentityFinisher[*HyperLogLog])
+}
@@ -0,0 +1,123 @@
+package fuego
+
+import (
+	"errors"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fnvString(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+
+	return h.Sum64()
+}
+
+func fnvInt(i int) uint64 {
+	return fnvString(strconv.Itoa(i))
+}
+
+func TestNewHyperLogLog(t *testing.T) {
+	assert.Equal(t, MinHyperLogLogPrecision, NewHyperLogLog(-1).Precision())
+	assert.Equal(t, MaxHyperLogLogPrecision, NewHyperLogLog(64).Precision())
+	assert.Equal(t, 12, NewHyperLogLog(12).Precision())
+	assert.InDelta(t, 0.0081, NewHyperLogLog(14).StandardError(), 1e-4)
+	assert.Equal(t, uint64(0), NewHyperLogLog(14).Count())
+}
+
+func TestHyperLogLog_Count(t *testing.T) {
+	for _, precision := range []int{MinHyperLogLogPrecision, 10, 14} {
+		for _, n := range []int{1, 10, 1_000, 10_000, 200_000} {
+			precision, n := precision, n
+
+			t.Run(strconv.Itoa(precision)+"/"+strconv.Itoa(n), func(t *testing.T) {
+				h := NewHyperLogLog(precision)
+
+				for i := 0; i < n; i++ {
+					h.AddHash(fnvInt(i))
+					h.AddHash(fnvInt(i)) // duplicates are not counted.
+				}
+
+				// the estimate is within 3 standard errors.
+				assert.InEpsilon(t, n, h.Count(), 3*h.StandardError())
+			})
+		}
+	}
+}
+
+func TestHyperLogLog_StandardError(t *testing.T) {
+	// the root mean square of the relative errors of many estimates is close to the
+	// standard error.
+	const (
+		trials = 200
+		n      = 20_000
+	)
+
+	h := NewHyperLogLog(10)
+	sumSquares := 0.0
+
+	for trial := 0; trial < trials; trial++ {
+		h = NewHyperLogLog(10)
+
+		for i := 0; i < n; i++ {
+			h.AddHash(fnvString(strconv.Itoa(trial) + ":" + strconv.Itoa(i)))
+		}
+
+		relErr := (float64(h.Count()) - n) / n
+		sumSquares += relErr * relErr
+	}
+
+	assert.InEpsilon(t, h.StandardError(), math.Sqrt(sumSquares/trials), 0.2)
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	h1, h2 := NewHyperLogLog(14), NewHyperLogLog(14)
+
+	for i := 0; i < 60_000; i++ {
+		h1.AddHash(fnvInt(i))
+	}
+
+	for i := 40_000; i < 100_000; i++ {
+		h2.AddHash(fnvInt(i))
+	}
+
+	count2 := h2.Count()
+
+	require.NoError(t, h1.Merge(h2))
+	require.NoError(t, h1.Merge(nil))
+	assert.InEpsilon(t, 100_000, h1.Count(), 3*h1.StandardError())
+	assert.Equal(t, count2, h2.Count(), "the merged HyperLogLog must be left unchanged")
+
+	err := h1.Merge(NewHyperLogLog(12))
+	assert.True(t, errors.Is(err, ErrIncompatibleSketches), err)
+}
+
+func TestCollector_ApproxCountDistinct(t *testing.T) {
+	values := make([]int, 50_000)
+	for idx := range values {
+		values[idx] = idx % 20_000
+	}
+
+	tt := map[string]Stream[int]{
+		"sequential": NewStreamFromSlice(values, 0),
+		"concurrent": NewStreamFromSlice(values, 10).Concurrent(4),
+	}
+
+	for name, stream := range tt {
+		stream := stream
+
+		t.Run(name, func(t *testing.T) {
+			c := ApproxCountDistinct(fnvInt, 12)
+			assert.True(t, c.IsConcurrent())
+
+			got := Collect(stream, c)
+			assert.Equal(t, 12, got.Precision())
+			assert.InEpsilon(t, 20_000, got.Count(), 3*got.StandardError())
+		})
+	}
+}
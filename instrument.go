@@ -0,0 +1,214 @@
+package fuego
+
+import (
+	"sync"
+	"time"
+)
+
+// stageHooks notifies the Metrics and the Observer of a stage of its activity.
+// All its methods are no-ops on a nil stageHooks.
+type stageHooks struct {
+	info     StageInfo
+	metrics  Metrics
+	observer Observer
+	started  sync.Once
+	closed   sync.Once
+}
+
+// hooks returns the hooks of the next stage created from this Stream by the given
+// operation, or nil when the stage is neither recorded nor observed.
+//
+// Only named stages are recorded by the Metrics (see Named). All stages are observed
+// by the Observer of the Stream, if any, or else by the global Observer, if any.
+func (s Stream[T]) hooks(operation string) *stageHooks {
+	var metrics Metrics
+	if s.name != "" {
+		metrics = s.metrics
+	}
+
+	observer := s.observer
+	if observer == nil {
+		observer = globalObserver()
+	}
+
+	if metrics == nil && observer == nil {
+		return nil
+	}
+
+	return &stageHooks{
+		info: StageInfo{
+			Operation: operation,
+			Name:      s.name,
+		},
+		metrics:  metrics,
+		observer: observer,
+	}
+}
+
+// start notifies that the stage started. Only the first call has an effect.
+func (h *stageHooks) start() {
+	if h != nil && h.observer != nil {
+		h.started.Do(func() { h.observer.OnStageStart(h.info) })
+	}
+}
+
+// close notifies that the stage closed. Only the first call has an effect.
+func (h *stageHooks) close() {
+	if h != nil && h.observer != nil {
+		h.closed.Do(func() { h.observer.OnStageClose(h.info) })
+	}
+}
+
+// in notifies that the stage received an element.
+func (h *stageHooks) in(element any) {
+	if h == nil {
+		return
+	}
+
+	if h.metrics != nil {
+		h.metrics.In(h.info.Name)
+	}
+
+	if h.observer != nil {
+		h.observer.OnElement(h.info, element)
+	}
+}
+
+// out notifies that the stage emitted an element.
+func (h *stageHooks) out() {
+	if h != nil && h.metrics != nil {
+		h.metrics.Out(h.info.Name)
+	}
+}
+
+// dropped notifies that the stage discarded an element.
+func (h *stageHooks) dropped() {
+	if h != nil && h.metrics != nil {
+		h.metrics.Dropped(h.info.Name)
+	}
+}
+
+// userFunction notifies the time spent in a user function that started at the given time.
+func (h *stageHooks) userFunction(start time.Time) {
+	if h != nil && h.metrics != nil {
+		h.metrics.UserFunction(h.info.Name, time.Since(start))
+	}
+}
+
+// sent notifies that an element was sent to the out-channel of the stage.
+// See Metrics.Sent.
+func (h *stageHooks) sent(length, capacity int, start time.Time) {
+	if h != nil && h.metrics != nil {
+		h.metrics.Sent(h.info.Name, length, capacity, time.Since(start))
+	}
+}
+
+// error notifies that a user function of the stage returned an error.
+func (h *stageHooks) error(err error) {
+	if h != nil && h.observer != nil {
+		h.observer.OnError(h.info, err)
+	}
+}
+
+// observePanic notifies a panic of a user function of the stage and resumes panicking.
+// It must be deferred.
+func (h *stageHooks) observePanic() {
+	if r := recover(); r != nil {
+		if h.observer != nil {
+			h.observer.OnPanic(h.info, r)
+		}
+
+		panic(r)
+	}
+}
+
+// instrumentFunction returns a Function that notifies the time spent in fn and its panics.
+func instrumentFunction[T, R any](h *stageHooks, fn Function[T, R]) Function[T, R] {
+	if h == nil {
+		return fn
+	}
+
+	return func(t T) R {
+		defer h.observePanic()
+		defer h.userFunction(time.Now())
+
+		return fn(t)
+	}
+}
+
+// instrumentPredicate returns a Predicate that notifies the time spent in p and its panics,
+// and notifies a dropped element each time p returns the value of drop.
+func instrumentPredicate[T any](h *stageHooks, p Predicate[T], drop bool) Predicate[T] {
+	if h == nil {
+		return p
+	}
+
+	fn := instrumentFunction(h, Function[T, bool](p))
+
+	return func(t T) bool {
+		res := fn(t)
+		if res == drop {
+			h.dropped()
+		}
+
+		return res
+	}
+}
+
+// instrumentInput returns a Function that notifies the elements it receives before
+// applying fn to them.
+func instrumentInput[T, R any](h *stageHooks, fn Function[T, R]) Function[T, R] {
+	if h == nil {
+		return fn
+	}
+
+	return func(t T) R {
+		h.in(t)
+		return fn(t)
+	}
+}
+
+// instrumentPull returns the pull function of a stage that notifies its start and its
+// close, as well as the elements it receives from the upstream pull function.
+// stage composes the upstream pull function with the stage's own.
+func instrumentPull[T, U any](h *stageHooks, pull func() (T, bool), stage func(func() (T, bool)) func() (U, bool)) func() (U, bool) {
+	if h == nil {
+		return stage(pull)
+	}
+
+	staged := stage(func() (T, bool) {
+		val, ok := pull()
+		if ok {
+			h.in(val)
+		}
+
+		return val, ok
+	})
+
+	return func() (U, bool) {
+		h.start()
+
+		val, ok := staged()
+		if !ok {
+			h.close()
+			return val, false
+		}
+
+		h.out()
+
+		return val, true
+	}
+}
+
+// send sends the element to the out-channel of the stage and notifies it.
+func send[T any](h *stageHooks, c chan<- T, val T) {
+	if h == nil {
+		c <- val
+		return
+	}
+
+	length, start := len(c), time.Now()
+	c <- val
+	h.sent(length, cap(c), start)
+	h.out()
+}
@@ -0,0 +1,93 @@
+//go:build go1.23
+
+package fuego
+
+import "iter"
+
+// FromSeq creates a new Stream from an iterator.
+//
+// The elements of the iterator are published to the stream after which the stream is closed.
+// The iterator is stopped early when the stream is released, e.g. when a loop over
+// Stream.All is exited with "break".
+func FromSeq[T any](seq iter.Seq[T], bufsize int) Stream[T] {
+	c := make(chan T, bufsize)
+	r := newReleaser()
+
+	go func() {
+		defer close(c)
+
+		for val := range seq {
+			select {
+			case c <- val:
+			case <-r.released():
+				return
+			}
+		}
+	}()
+
+	s := NewStream(c).planned("FromSeq", "")
+	s.releaser = r
+
+	return s
+}
+
+// FromSeq2 creates a new Stream of Pairs from an iterator of key-value pairs, such as
+// produced by maps.All.
+//
+// See FromSeq for details.
+func FromSeq2[K, V any](seq iter.Seq2[K, V], bufsize int) Stream[Pair[K, V]] {
+	return FromSeq(func(yield func(Pair[K, V]) bool) {
+		for k, v := range seq {
+			if !yield(NewPair(k, v)) {
+				return
+			}
+		}
+	}, bufsize)
+}
+
+// NewStreamFromIterator creates a new Stream from a pull-style iterator.
+//
+// next is called repeatedly until it returns false, at which point the stream is closed.
+// next is not called anymore once the stream is released, e.g. when a loop over
+// Stream.All is exited with "break".
+//
+// Like NewStreamFromSlice, the stream is synchronous. See Stream.Async.
+func NewStreamFromIterator[T any](next func() (T, bool), bufsize int) Stream[T] {
+	return newPullStream(next, bufsize).planned("NewStreamFromIterator", "")
+}
+
+// All returns an iterator over the elements of this stream, for use in a "for range" loop
+// or with functions of the standard library such as slices.Collect.
+//
+// Exiting the loop early (e.g. with "break") releases the stream: its source stops
+// producing elements and the upstream stages complete. This is only possible when the
+// source is owned by fuego (e.g. NewStreamFromSlice, FromSeq) and is not separated from
+// this stream by a method that splits the stream (e.g. Tee). Otherwise, the remaining
+// elements are left unread on the stream.
+//
+// This is a continuous terminal operation. It will only complete if the producer closes
+// the stream or the loop is exited.
+func (s Stream[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for val, ok := s.receive(); ok; val, ok = s.receive() {
+			if !yield(val) {
+				s.release()
+				return
+			}
+		}
+	}
+}
+
+// AllPairs returns an iterator over the key-value pairs of a stream of Pairs, for use in
+// a "for range" loop or with functions of the standard library such as maps.Collect.
+//
+// See Stream.All for details.
+func AllPairs[K, V any](s Stream[Pair[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for p := range s.All() {
+			if !yield(p.Left, p.Right) {
+				return
+			}
+		}
+	}
+}
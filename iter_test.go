@@ -0,0 +1,157 @@
+//go:build go1.23
+
+package fuego
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// naturals returns an infinite iterator of natural numbers.
+// stopped is closed when the iterator returns.
+func naturals(stopped chan struct{}) func(yield func(int) bool) {
+	return func(yield func(int) bool) {
+		defer close(stopped)
+
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	got := FromSeq(slices.Values([]int{1, 2, 3}), 0).ToSlice()
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	got = FromSeq(slices.Values([]int{}), 0).ToSlice()
+	assert.Equal(t, []int{}, got)
+}
+
+func TestFromSeq2(t *testing.T) {
+	got := FromSeq2(slices.All([]string{"a", "b"}), 0).ToSlice()
+	assert.Equal(t, []Pair[int, string]{{0, "a"}, {1, "b"}}, got)
+}
+
+func TestNewStreamFromIterator(t *testing.T) {
+	i := 0
+	next := func() (int, bool) {
+		i++
+		return i * 10, i <= 3
+	}
+
+	got := NewStreamFromIterator(next, 0).ToSlice()
+	assert.Equal(t, []int{10, 20, 30}, got)
+}
+
+func TestStream_All(t *testing.T) {
+	tt := map[string]struct {
+		stream Stream[int]
+		want   []int
+	}{
+		"Should not iterate when nil in-stream": {
+			stream: Stream[int]{stream: nil},
+			want:   nil,
+		},
+		"Should iterate over all elements": {
+			stream: NewStreamFromSlice([]int{1, 2, 3}, 0),
+			want:   []int{1, 2, 3},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			var got []int
+			for val := range tc.stream.All() {
+				got = append(got, val)
+			}
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStream_All_SlicesCollect(t *testing.T) {
+	got := slices.Collect(Map(NewStreamFromSlice([]int{1, 2, 3}, 0), func(i int) int { return i * i }).All())
+	assert.Equal(t, []int{1, 4, 9}, got)
+}
+
+func TestAllPairs_MapsCollect(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	got := maps.Collect(AllPairs(FromSeq2(maps.All(m), 0)))
+	assert.Equal(t, m, got)
+}
+
+func TestStream_All_BreakReleasesUpstreamStages(t *testing.T) {
+	stopped := make(chan struct{})
+
+	s := FromSeq(naturals(stopped), 10).
+		Filter(func(i int) bool { return i%2 == 0 }).
+		Concurrent(4).
+		Peek(func(int) {})
+
+	got := []int{}
+
+	for val := range Map(s, func(i int) int { return i / 2 }).All() {
+		if val == 5 {
+			break
+		}
+		got = append(got, val)
+	}
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, got)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("the source was not released")
+	}
+}
+
+func TestStream_All_BreakDoesNotReleaseSiblingStreams(t *testing.T) {
+	streams := NewStreamFromSlice([]int{1, 2, 3, 4}, 0).Tee(2)
+
+	result := make(chan []int)
+	go func() { result <- streams[1].ToSlice() }()
+
+	for range streams[0].All() {
+		break
+	}
+
+	go streams[0].ForEach(func(int) {})
+
+	assert.Equal(t, []int{1, 2, 3, 4}, <-result)
+}
+
+func TestStream_All_BreakDoesNotDrainUserChannel(t *testing.T) {
+	c := make(chan int, 3)
+	c <- 1
+	c <- 2
+	c <- 3
+
+	for range NewStream(c).All() {
+		break
+	}
+
+	assert.Equal(t, 2, <-c)
+}
+
+func TestFoldWhile_ReleasesSource(t *testing.T) {
+	got := FoldWhile(FromSeq(func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}, 0), 0, func(acc, i int) int { return acc + i }, func(acc int) bool { return acc < 10 })
+
+	assert.Equal(t, 10, got)
+}
@@ -0,0 +1,268 @@
+package fuego
+
+// NOTICE:
+// Joins are functions rather than methods of Stream because
+// Go does not support parameterised methods. See doc.go.
+
+// Pair is a tuple of two values, such as produced by the joins of two Streams.
+type Pair[L, R any] struct {
+	Left  L
+	Right R
+}
+
+// NewPair creates a new Pair.
+func NewPair[L, R any](left L, right R) Pair[L, R] {
+	return Pair[L, R]{
+		Left:  left,
+		Right: right,
+	}
+}
+
+// HashJoin returns a Stream of the Pairs of elements of the left and right Streams
+// that have equal keys (i.e. an inner join).
+//
+// The right Stream is entirely read into memory first (it should be the smaller of the two),
+// after which the left Stream is streamed. The Pairs are emitted in the order of the left
+// Stream and, for a given left element, in the order of the right Stream.
+//
+// The resulting Stream has the settings (such as concurrency) of the left Stream.
+//
+// This function streams continuously until the left in-stream is closed at
+// which point the out-stream will be closed too.
+func HashJoin[L, R any, K comparable](left Stream[L], right Stream[R], leftKey Function[L, K], rightKey Function[R, K]) Stream[Pair[L, R]] {
+	left = left.Async()
+	outstream := make(chan Pair[L, R], cap(left.stream))
+
+	go func() {
+		defer close(outstream)
+
+		lookup := buildJoinLookup(right, rightKey)
+
+		left.ForEach(func(l L) {
+			for _, r := range lookup.values[leftKey(l)] {
+				outstream <- NewPair(l, r)
+			}
+		})
+	}()
+
+	return derivedStream(left, outstream).planned("HashJoin", left.name, left.Describe(), right.Describe())
+}
+
+// LeftOuterHashJoin is akin to HashJoin but also emits the elements of the left Stream
+// that have no match in the right Stream, paired with an empty Optional.
+//
+// See HashJoin for further details.
+func LeftOuterHashJoin[L, R any, K comparable](left Stream[L], right Stream[R], leftKey Function[L, K], rightKey Function[R, K]) Stream[Pair[L, Optional[R]]] {
+	left = left.Async()
+	outstream := make(chan Pair[L, Optional[R]], cap(left.stream))
+
+	go func() {
+		defer close(outstream)
+
+		lookup := buildJoinLookup(right, rightKey)
+
+		left.ForEach(func(l L) {
+			rs, ok := lookup.values[leftKey(l)]
+			if !ok {
+				outstream <- NewPair(l, OptionalEmpty[R]())
+				return
+			}
+
+			for _, r := range rs {
+				outstream <- NewPair(l, OptionalOf(r))
+			}
+		})
+	}()
+
+	return derivedStream(left, outstream).planned("LeftOuterHashJoin", left.name, left.Describe(), right.Describe())
+}
+
+// FullOuterHashJoin is akin to HashJoin but also emits the elements of either Stream
+// that have no match in the other Stream, paired with an empty Optional.
+//
+// The unmatched elements of the right Stream are emitted, in their original order,
+// after the left Stream was entirely consumed.
+//
+// See HashJoin for further details.
+func FullOuterHashJoin[L, R any, K comparable](left Stream[L], right Stream[R], leftKey Function[L, K], rightKey Function[R, K]) Stream[Pair[Optional[L], Optional[R]]] {
+	left = left.Async()
+	outstream := make(chan Pair[Optional[L], Optional[R]], cap(left.stream))
+
+	go func() {
+		defer close(outstream)
+
+		lookup := buildJoinLookup(right, rightKey)
+		matchedKeys := map[K]struct{}{}
+
+		left.ForEach(func(l L) {
+			key := leftKey(l)
+
+			rs, ok := lookup.values[key]
+			if !ok {
+				outstream <- NewPair(OptionalOf(l), OptionalEmpty[R]())
+				return
+			}
+
+			matchedKeys[key] = struct{}{}
+
+			for _, r := range rs {
+				outstream <- NewPair(OptionalOf(l), OptionalOf(r))
+			}
+		})
+
+		for _, key := range lookup.keys {
+			if _, ok := matchedKeys[key]; ok {
+				continue
+			}
+
+			for _, r := range lookup.values[key] {
+				outstream <- NewPair(OptionalEmpty[L](), OptionalOf(r))
+			}
+		}
+	}()
+
+	return derivedStream(left, outstream).planned("FullOuterHashJoin", left.name, left.Describe(), right.Describe())
+}
+
+// joinLookup is the in-memory hash table of a hash join.
+type joinLookup[K comparable, R any] struct {
+	keys   []K // keys in order of first appearance
+	values map[K][]R
+}
+
+// buildJoinLookup reads the Stream entirely into a joinLookup.
+func buildJoinLookup[K comparable, R any](s Stream[R], keyFn Function[R, K]) joinLookup[K, R] {
+	lookup := joinLookup[K, R]{
+		keys:   []K{},
+		values: map[K][]R{},
+	}
+
+	s.ForEach(func(r R) {
+		key := keyFn(r)
+
+		if _, ok := lookup.values[key]; !ok {
+			lookup.keys = append(lookup.keys, key)
+		}
+
+		lookup.values[key] = append(lookup.values[key], r)
+	})
+
+	return lookup
+}
+
+// BroadcastJoin returns a Stream of the Pairs of elements of the left Stream and of
+// the values of the lookup map that have equal keys (i.e. an inner join).
+//
+// The lookup map is typically a small reference data set held in memory, such as obtained
+// with Collect and ToMap. It must not be modified while the join is in progress.
+//
+// The resulting Stream has the settings (such as concurrency) of the left Stream.
+//
+// This function streams continuously until the left in-stream is closed at
+// which point the out-stream will be closed too.
+func BroadcastJoin[L any, K comparable, V any](left Stream[L], leftKey Function[L, K], lookup map[K]V) Stream[Pair[L, V]] {
+	left = left.Async()
+	outstream := make(chan Pair[L, V], cap(left.stream))
+
+	go func() {
+		defer close(outstream)
+
+		left.ForEach(func(l L) {
+			if v, ok := lookup[leftKey(l)]; ok {
+				outstream <- NewPair(l, v)
+			}
+		})
+	}()
+
+	return planStage(left, derivedStream(left, outstream), "BroadcastJoin")
+}
+
+// MergeJoin returns a Stream of the Pairs of elements of the left and right Streams
+// that have equal keys (i.e. an inner join).
+//
+// Both Streams MUST be sorted in ascending order of their keys. Unlike HashJoin, MergeJoin
+// does not read either Stream into memory: it only retains the elements of the right Stream
+// that share the key currently being joined. When keys are unique in the right Stream,
+// memory usage is constant.
+//
+// The join completes as soon as either Stream is exhausted: the remaining elements of the
+// other Stream are NOT consumed. When its source is owned by fuego (e.g. NewStreamFromSlice),
+// the other Stream is released so that its Go routines complete.
+//
+// The resulting Stream has the settings (such as concurrency) of the left Stream.
+//
+// This function streams continuously until either in-stream is closed at
+// which point the out-stream will be closed too.
+func MergeJoin[L, R any, K Comparable](left Stream[L], right Stream[R], leftKey Function[L, K], rightKey Function[R, K]) Stream[Pair[L, R]] {
+	left = left.Async()
+	outstream := make(chan Pair[L, R], cap(left.stream))
+
+	go func() {
+		defer close(outstream)
+		defer left.release()
+		defer right.release()
+
+		l, lok := left.receive()
+		r, rok := right.receive()
+
+		run := []R{}
+
+		for lok && rok {
+			lk, rk := leftKey(l), rightKey(r)
+
+			switch {
+			case lk < rk:
+				l, lok = left.receive()
+
+			case lk > rk:
+				r, rok = right.receive()
+
+			default:
+				run = append(run[:0], r)
+
+				for r, rok = right.receive(); rok && rightKey(r) == rk; r, rok = right.receive() {
+					run = append(run, r)
+				}
+
+				for ; lok && leftKey(l) == lk; l, lok = left.receive() {
+					for _, rr := range run {
+						outstream <- NewPair(l, rr)
+					}
+				}
+			}
+		}
+	}()
+
+	return derivedStream(left, outstream).planned("MergeJoin", left.name, left.Describe(), right.Describe())
+}
+
+// CrossProduct returns a Stream of all the Pairs of elements of the left and right Streams
+// (i.e. the Cartesian product).
+//
+// The right Stream is entirely read into memory first, after which the left Stream is
+// streamed. This is intended for small Streams: the out-stream has as many elements as
+// the product of the number of elements in both Streams.
+//
+// The resulting Stream has the settings (such as concurrency) of the left Stream.
+//
+// This function streams continuously until the left in-stream is closed at
+// which point the out-stream will be closed too.
+func CrossProduct[L, R any](left Stream[L], right Stream[R]) Stream[Pair[L, R]] {
+	left = left.Async()
+	outstream := make(chan Pair[L, R], cap(left.stream))
+
+	go func() {
+		defer close(outstream)
+
+		rs := []R{}
+		right.ForEach(func(r R) { rs = append(rs, r) })
+
+		left.ForEach(func(l L) {
+			for _, r := range rs {
+				outstream <- NewPair(l, r)
+			}
+		})
+	}()
+
+	return derivedStream(left, outstream).planned("CrossProduct", left.name, left.Describe(), right.Describe())
+}
@@ -0,0 +1,258 @@
+package fuego
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type order struct {
+	id         int
+	customerID int
+}
+
+func (o order) CustomerID() int {
+	return o.customerID
+}
+
+type customer struct {
+	id   int
+	name string
+}
+
+func (c customer) ID() int {
+	return c.id
+}
+
+func getOrdersSample() []order {
+	return []order{
+		{id: 1, customerID: 10},
+		{id: 2, customerID: 30},
+		{id: 3, customerID: 10},
+		{id: 4, customerID: 40},
+	}
+}
+
+func getCustomersSample() []customer {
+	return []customer{
+		{id: 10, name: "Ten"},
+		{id: 20, name: "Twenty"},
+		{id: 30, name: "Thirty"},
+		{id: 30, name: "Thirty bis"},
+	}
+}
+
+func TestHashJoin(t *testing.T) {
+	tt := map[string]struct {
+		left  Stream[order]
+		right Stream[customer]
+		want  []Pair[order, customer]
+	}{
+		"Should return an empty stream when nil in-streams": {
+			left:  Stream[order]{stream: nil},
+			right: Stream[customer]{stream: nil},
+			want:  []Pair[order, customer]{},
+		},
+		"Should return an empty stream when nil right in-stream": {
+			left:  NewStreamFromSlice(getOrdersSample(), 0),
+			right: Stream[customer]{stream: nil},
+			want:  []Pair[order, customer]{},
+		},
+		"Should join matching elements": {
+			left:  NewStreamFromSlice(getOrdersSample(), 0),
+			right: NewStreamFromSlice(getCustomersSample(), 0),
+			want: []Pair[order, customer]{
+				{Left: order{id: 1, customerID: 10}, Right: customer{id: 10, name: "Ten"}},
+				{Left: order{id: 2, customerID: 30}, Right: customer{id: 30, name: "Thirty"}},
+				{Left: order{id: 2, customerID: 30}, Right: customer{id: 30, name: "Thirty bis"}},
+				{Left: order{id: 3, customerID: 10}, Right: customer{id: 10, name: "Ten"}},
+			},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := HashJoin(tc.left, tc.right, order.CustomerID, customer.ID).ToSlice()
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLeftOuterHashJoin(t *testing.T) {
+	got := LeftOuterHashJoin(
+		NewStreamFromSlice(getOrdersSample(), 0),
+		NewStreamFromSlice(getCustomersSample(), 0),
+		order.CustomerID,
+		customer.ID,
+	).ToSlice()
+
+	want := []Pair[order, Optional[customer]]{
+		{Left: order{id: 1, customerID: 10}, Right: OptionalOf(customer{id: 10, name: "Ten"})},
+		{Left: order{id: 2, customerID: 30}, Right: OptionalOf(customer{id: 30, name: "Thirty"})},
+		{Left: order{id: 2, customerID: 30}, Right: OptionalOf(customer{id: 30, name: "Thirty bis"})},
+		{Left: order{id: 3, customerID: 10}, Right: OptionalOf(customer{id: 10, name: "Ten"})},
+		{Left: order{id: 4, customerID: 40}, Right: OptionalEmpty[customer]()},
+	}
+
+	assert.Equal(t, want, got)
+}
+
+func TestFullOuterHashJoin(t *testing.T) {
+	got := FullOuterHashJoin(
+		NewStreamFromSlice(getOrdersSample(), 0),
+		NewStreamFromSlice(getCustomersSample(), 0),
+		order.CustomerID,
+		customer.ID,
+	).ToSlice()
+
+	want := []Pair[Optional[order], Optional[customer]]{
+		{Left: OptionalOf(order{id: 1, customerID: 10}), Right: OptionalOf(customer{id: 10, name: "Ten"})},
+		{Left: OptionalOf(order{id: 2, customerID: 30}), Right: OptionalOf(customer{id: 30, name: "Thirty"})},
+		{Left: OptionalOf(order{id: 2, customerID: 30}), Right: OptionalOf(customer{id: 30, name: "Thirty bis"})},
+		{Left: OptionalOf(order{id: 3, customerID: 10}), Right: OptionalOf(customer{id: 10, name: "Ten"})},
+		{Left: OptionalOf(order{id: 4, customerID: 40}), Right: OptionalEmpty[customer]()},
+		{Left: OptionalEmpty[order](), Right: OptionalOf(customer{id: 20, name: "Twenty"})},
+	}
+
+	assert.Equal(t, want, got)
+}
+
+func TestBroadcastJoin(t *testing.T) {
+	customerNames := map[int]string{
+		10: "Ten",
+		30: "Thirty",
+	}
+
+	got := BroadcastJoin(
+		NewStreamFromSlice(getOrdersSample(), 0).Concurrent(2),
+		order.CustomerID,
+		customerNames,
+	)
+
+	assert.Equal(t, 2, got.Concurrency())
+
+	want := []Pair[order, string]{
+		{Left: order{id: 1, customerID: 10}, Right: "Ten"},
+		{Left: order{id: 2, customerID: 30}, Right: "Thirty"},
+		{Left: order{id: 3, customerID: 10}, Right: "Ten"},
+	}
+
+	assert.Equal(t, want, got.ToSlice())
+}
+
+func TestMergeJoin(t *testing.T) {
+	tt := map[string]struct {
+		left  []int
+		right []int
+		want  []Pair[int, int]
+	}{
+		"Should return an empty stream when empty in-streams": {
+			left:  []int{},
+			right: []int{},
+			want:  []Pair[int, int]{},
+		},
+		"Should return an empty stream when no match": {
+			left:  []int{1, 3, 5},
+			right: []int{2, 4, 6},
+			want:  []Pair[int, int]{},
+		},
+		"Should join unique keys": {
+			left:  []int{1, 2, 3, 5, 8},
+			right: []int{0, 2, 3, 4, 8, 9},
+			want:  []Pair[int, int]{{2, 2}, {3, 3}, {8, 8}},
+		},
+		"Should join duplicate keys on both sides": {
+			left:  []int{1, 2, 2, 3, 4, 4},
+			right: []int{2, 2, 2, 4},
+			want: []Pair[int, int]{
+				{2, 2}, {2, 2}, {2, 2},
+				{2, 2}, {2, 2}, {2, 2},
+				{4, 4},
+				{4, 4},
+			},
+		},
+		"Should stop when the right stream is exhausted": {
+			left:  []int{1, 2, 3, 4, 5},
+			right: []int{1, 2},
+			want:  []Pair[int, int]{{1, 1}, {2, 2}},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := MergeJoin(
+				NewStreamFromSlice(tc.left, 0),
+				NewStreamFromSlice(tc.right, 0),
+				Identity[int],
+				Identity[int],
+			).ToSlice()
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMergeJoin_NilStream(t *testing.T) {
+	got := MergeJoin(
+		Stream[int]{stream: nil},
+		NewStreamFromSlice([]int{1, 2}, 0),
+		Identity[int],
+		Identity[int],
+	).ToSlice()
+
+	assert.Equal(t, []Pair[int, int]{}, got)
+}
+
+func TestMergeJoin_ReleasesTheOtherStream(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 50; i++ {
+		got := MergeJoin(
+			NewStreamFromSlice(intRange(100), 0),
+			NewStreamFromSlice([]int{1, 2}, 0).Async(),
+			Identity[int],
+			Identity[int],
+		).ToSlice()
+		assert.Equal(t, []Pair[int, int]{{1, 1}, {2, 2}}, got)
+
+		got = MergeJoin(
+			NewStreamFromSlice([]int{1, 2}, 0),
+			NewStreamFromSlice(intRange(100), 0).Async(),
+			Identity[int],
+			Identity[int],
+		).ToSlice()
+		assert.Equal(t, []Pair[int, int]{{1, 1}, {2, 2}}, got)
+	}
+
+	// assert.Eventually is not used since it runs the condition in its own Go routine.
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before, "the Go routines of the Streams must complete")
+}
+
+func TestCrossProduct(t *testing.T) {
+	got := CrossProduct(
+		NewStreamFromSlice([]int{1, 2}, 0),
+		NewStreamFromSlice([]string{"a", "b", "c"}, 0),
+	).ToSlice()
+
+	want := []Pair[int, string]{
+		{1, "a"}, {1, "b"}, {1, "c"},
+		{2, "a"}, {2, "b"}, {2, "c"},
+	}
+
+	assert.Equal(t, want, got)
+}
+
+func TestNewPair(t *testing.T) {
+	assert.Equal(t, Pair[int, string]{Left: 1, Right: "a"}, NewPair(1, "a"))
+}
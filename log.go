@@ -1,52 +1,118 @@
 package fuego
 
 import (
-	"os"
+	"sync/atomic"
 
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 )
 
-// Logger exposes basic logging methods.
-// This is provided as a sheer convenience.
+// Logger is the logging interface used by fuego.
+//
+// keysAndValues are alternating keys and values, e.g. "value", 1. This is the
+// convention of log/slog and of zap's SugaredLogger.
+//
+// Loggers are attached to a Stream with Stream.WithLogger or globally with SetLogger.
+// No logging takes place by default.
+//
+// The methods of Logger are called concurrently and must therefore be safe for
+// concurrent use.
 type Logger interface {
-	Debug(msg string, fields ...zap.Field)
-	Info(msg string, fields ...zap.Field)
-	Warn(msg string, fields ...zap.Field)
-	Error(msg string, fields ...zap.Field)
-	With(fields ...zap.Field) *zap.Logger
-}
-
-// init initialises zap's global loggers (i.e. zap.L() and zap.S().
-// Log level is retrieved from environment variable LOB_LEVEL, defaulting to "info" otherwise.
-// To activate this facility, add a blank import to this package.
-// nolint: gochecknoinits
-func init() {
-	level := os.Getenv("FUEGO_LOG_LEVEL")
-	if level == "" {
-		level = "error"
+	Debug(msg string, keysAndValues ...any)
+	Info(msg string, keysAndValues ...any)
+	Warn(msg string, keysAndValues ...any)
+	Error(msg string, keysAndValues ...any)
+}
+
+// NopLogger is a Logger that discards all messages.
+//
+// It is useful to disable logging for a Stream when a global Logger is set (see SetLogger).
+type NopLogger struct{}
+
+var _ Logger = NopLogger{}
+
+// Debug implements Logger.
+func (NopLogger) Debug(string, ...any) {}
+
+// Info implements Logger.
+func (NopLogger) Info(string, ...any) {}
+
+// Warn implements Logger.
+func (NopLogger) Warn(string, ...any) {}
+
+// Error implements Logger.
+func (NopLogger) Error(string, ...any) {}
+
+// zapLogger adapts a zap.SugaredLogger to Logger.
+type zapLogger struct {
+	sugar *zap.SugaredLogger
+}
+
+// NewZapLogger returns a Logger that logs to the supplied zap.Logger, or to zap's global
+// logger (i.e. zap.L()) when l is nil.
+func NewZapLogger(l *zap.Logger) Logger {
+	if l == nil {
+		l = zap.L()
+	}
+
+	return zapLogger{sugar: l.Sugar()}
+}
+
+// Debug implements Logger.
+func (l zapLogger) Debug(msg string, keysAndValues ...any) {
+	l.sugar.Debugw(msg, keysAndValues...)
+}
+
+// Info implements Logger.
+func (l zapLogger) Info(msg string, keysAndValues ...any) {
+	l.sugar.Infow(msg, keysAndValues...)
+}
+
+// Warn implements Logger.
+func (l zapLogger) Warn(msg string, keysAndValues ...any) {
+	l.sugar.Warnw(msg, keysAndValues...)
+}
+
+// Error implements Logger.
+func (l zapLogger) Error(msg string, keysAndValues ...any) {
+	l.sugar.Errorw(msg, keysAndValues...)
+}
+
+// WithLogger attaches a Logger to this Stream. It takes precedence over the
+// global Logger (see SetLogger).
+//
+// The Logger is inherited by the Streams derived from this Stream.
+func (s Stream[T]) WithLogger(l Logger) Stream[T] {
+	s.logger = l
+	return s
+}
+
+// log returns the Logger of this Stream, if any, or else the global Logger, if any.
+func (s Stream[T]) log() Logger {
+	if s.logger != nil {
+		return s.logger
 	}
 
-	initialiseZapGlobals(level)
+	return globalLogger()
 }
 
-// Option is a function to provide the Logger creation with extra initialisation options.
-type Option func(*zap.Config)
+// loggerHolder allows to store a nil Logger in an atomic.Value.
+type loggerHolder struct {
+	logger Logger
+}
 
-func initialiseZapGlobals(level string) {
-	zapLevel := zap.InfoLevel
-	_ = zapLevel.UnmarshalText([]byte(level))
+var globalLoggerValue atomic.Value // nolint: gochecknoglobals
 
-	lc := zap.NewProductionConfig()
-	lc.Level = zap.NewAtomicLevelAt(zapLevel)
-	lc.OutputPaths = []string{"stdout"}
-	lc.ErrorOutputPaths = []string{"stderr"}
-	lc.EncoderConfig.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+// SetLogger sets the global Logger, i.e. the Logger of the Streams that do not
+// have one attached with Stream.WithLogger. nil removes the global Logger.
+func SetLogger(l Logger) {
+	globalLoggerValue.Store(loggerHolder{logger: l})
+}
 
-	logger, err := lc.Build()
-	if err != nil {
-		panic(err)
+// globalLogger returns the global Logger, if any.
+func globalLogger() Logger {
+	if h, ok := globalLoggerValue.Load().(loggerHolder); ok {
+		return h.logger
 	}
 
-	zap.ReplaceGlobals(logger)
+	return nil
 }
@@ -0,0 +1,18 @@
+//go:build go1.21
+
+package fuego
+
+import "log/slog"
+
+// *slog.Logger implements Logger as is.
+var _ Logger = (*slog.Logger)(nil)
+
+// NewSlogLogger returns a Logger that logs to the supplied slog.Logger, or to slog's
+// default logger (i.e. slog.Default()) when l is nil.
+func NewSlogLogger(l *slog.Logger) Logger {
+	if l == nil {
+		return slog.Default()
+	}
+
+	return l
+}
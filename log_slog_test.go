@@ -0,0 +1,29 @@
+//go:build go1.21
+
+package fuego
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSlogLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := NewSlogLogger(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+
+	NewStreamFromSlice([]int{1}, 0).WithLogger(l).ForEach(func(int) {})
+
+	assert.Equal(t, "level=DEBUG msg=\"calling consumer\" value=1\n", buf.String())
+	assert.Equal(t, slog.Default(), NewSlogLogger(nil))
+}
@@ -0,0 +1,125 @@
+package fuego
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// recordingLogger records the messages it receives as strings.
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) record(level, msg string, keysAndValues ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.messages = append(l.messages, strings.TrimSuffix(fmt.Sprintln(append([]any{level, msg}, keysAndValues...)...), "\n"))
+}
+
+func (l *recordingLogger) Debug(msg string, keysAndValues ...any) {
+	l.record("DEBUG", msg, keysAndValues...)
+}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...any) {
+	l.record("INFO", msg, keysAndValues...)
+}
+
+func (l *recordingLogger) Warn(msg string, keysAndValues ...any) {
+	l.record("WARN", msg, keysAndValues...)
+}
+
+func (l *recordingLogger) Error(msg string, keysAndValues ...any) {
+	l.record("ERROR", msg, keysAndValues...)
+}
+
+func TestStream_WithLogger(t *testing.T) {
+	tt := map[string]struct {
+		stream Stream[int]
+		want   []string
+	}{
+		"Should log nil stream": {
+			stream: Stream[int]{},
+			want:   []string{"DEBUG empty stream"},
+		},
+		"Should log consumed elements": {
+			stream: NewStreamFromSlice([]int{1, 2}, 0),
+			want:   []string{"DEBUG calling consumer value 1", "DEBUG calling consumer value 2"},
+		},
+		"Should inherit logger in derived streams": {
+			stream: Map(NewStreamFromSlice([]int{1, 2}, 0).Async(), func(i int) int { return i * 10 }),
+			want:   []string{"DEBUG calling consumer value 10", "DEBUG calling consumer value 20"},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			l := &recordingLogger{}
+
+			var got []int
+			tc.stream.WithLogger(l).ForEach(func(i int) { got = append(got, i) })
+
+			assert.Equal(t, tc.want, l.messages)
+		})
+	}
+}
+
+func TestStream_WithLogger_MapWithRetry(t *testing.T) {
+	l := &recordingLogger{}
+	errTransient := errors.New("transient")
+
+	got := C(NewStreamFromSlice([]int{1}, 0).
+		WithLogger(l).
+		MapWithRetry(RetryPolicy[int]{MaxAttempts: 2}, failingTimesTwo(map[int]int{1: 1}, errTransient)), Int).
+		ToSlice()
+
+	assert.Equal(t, []int{2}, got)
+	assert.Equal(t, []string{"DEBUG mapper failed value 1 error transient"}, l.messages)
+}
+
+func TestSetLogger(t *testing.T) {
+	global, local := &recordingLogger{}, &recordingLogger{}
+
+	SetLogger(global)
+	defer SetLogger(nil)
+
+	NewStreamFromSlice([]int{1}, 0).ForEach(func(int) {})
+	NewStreamFromSlice([]int{2}, 0).WithLogger(local).ForEach(func(int) {})
+	NewStreamFromSlice([]int{3}, 0).WithLogger(NopLogger{}).ForEach(func(int) {})
+
+	SetLogger(nil)
+	NewStreamFromSlice([]int{4}, 0).ForEach(func(int) {})
+
+	assert.Equal(t, []string{"DEBUG calling consumer value 1"}, global.messages)
+	assert.Equal(t, []string{"DEBUG calling consumer value 2"}, local.messages)
+}
+
+func TestNewZapLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := NewZapLogger(zap.New(core))
+
+	l.Debug("debug", "k", 1)
+	l.Info("info")
+	l.Warn("warn")
+	l.Error("error")
+
+	got := []string{}
+	for _, e := range logs.All() {
+		got = append(got, e.Level.String()+" "+e.Message)
+	}
+
+	assert.Equal(t, []string{"debug debug", "info info", "warn warn", "error error"}, got)
+	assert.Equal(t, map[string]any{"k": int64(1)}, logs.All()[0].ContextMap())
+	assert.NotNil(t, NewZapLogger(nil))
+}
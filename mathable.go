@@ -7,45 +7,72 @@ type MathableStream[T Mathable] struct {
 
 // Sum return the sum of all items on the stream.
 // Panics if the channel is nil or the stream is empty.
+// See TrySum for a variant that returns an error instead.
 // This is a special case of a reduction.
 // This is a terminal operation and hence expects the producer to close the stream in order to complete.
 func (s MathableStream[T]) Sum() T {
-	if s.stream == nil {
-		panic(PanicMissingChannel)
+	sum, err := s.TrySum()
+	if err != nil {
+		panic(err)
 	}
 
-	sum, ok := <-s.stream
+	return sum
+}
+
+// TrySum return the sum of all items on the stream.
+// Unlike Sum, it does not panic: it returns ErrMissingChannel if the channel is nil
+// and ErrNoSuchElement if the stream is empty.
+// This is a terminal operation and hence expects the producer to close the stream in order to complete.
+func (s MathableStream[T]) TrySum() (T, error) {
+	if s.isNil() {
+		var zero T
+		return zero, ErrMissingChannel
+	}
+
+	sum, ok := s.receive()
 	if !ok {
-		panic(PanicNoSuchElement)
+		return sum, ErrNoSuchElement
 	}
 
-	for val := range s.stream {
+	for val, ok := s.receive(); ok; val, ok = s.receive() {
 		sum = Sum(sum, val)
 	}
 
-	return sum
+	return sum, nil
 }
 
 // Average returns the arithmetic average of the numbers in the stream.
+// The average is computed in T: for integer types, the result is truncated.
+// See Stats for an average computed in float64.
 // Panics if the channel is nil or the stream is empty.
 // This is a special case of a reduction.
 // This is a terminal operation and hence expects the producer to close the stream in order to complete.
 func (s MathableStream[T]) Average() T {
-	if s.stream == nil {
-		panic(PanicMissingChannel)
+	if s.isNil() {
+		panic(ErrMissingChannel)
 	}
 
-	sum, ok := <-s.stream
+	sum, ok := s.receive()
 	if !ok {
-		panic(PanicNoSuchElement)
+		panic(ErrNoSuchElement)
 	}
 
 	var cnt T = 1
 
-	for val := range s.stream {
+	for val, ok := s.receive(); ok; val, ok = s.receive() {
 		sum += val
 		cnt++
 	}
 
 	return sum / cnt
 }
+
+// Stats returns a summary of the numbers in the stream: count, sum, min, max, mean,
+// variance and standard deviation.
+// The summary of an empty stream has a Count of 0.
+// Panics if the channel is nil.
+// When the stream is concurrent, the summary is computed in parallel (see Summarizing).
+// This is a terminal operation and hence expects the producer to close the stream in order to complete.
+func (s MathableStream[T]) Stats() Stats[T] {
+	return Collect(s.Stream, Summarizing[T]())
+}
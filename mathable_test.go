@@ -1,6 +1,7 @@
 package fuego
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -46,6 +47,37 @@ func TestMathableStream_Sum(t *testing.T) {
 	}
 }
 
+func TestMathableStream_TrySum(t *testing.T) {
+	tt := map[string]struct {
+		stream  MathableStream[int]
+		want    int
+		wantErr error
+	}{
+		"Should return error for a Stream of nil": {
+			stream:  MathableStream[int]{},
+			wantErr: ErrMissingChannel,
+		},
+		"Should return error for an empty Stream": {
+			stream:  MathableStream[int]{NewStreamFromSlice([]int{}, 0)},
+			wantErr: ErrNoSuchElement,
+		},
+		"Should return the sum": {
+			stream: MathableStream[int]{NewStreamFromSlice([]int{1, -2, 3}, 0)},
+			want:   2,
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.stream.TrySum()
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestMathableStream_Average(t *testing.T) {
 	tt := map[string]struct {
 		got  func() interface{}
@@ -85,3 +117,72 @@ func TestMathableStream_Average(t *testing.T) {
 		})
 	}
 }
+
+func TestMathableStream_Stats(t *testing.T) {
+	tt := map[string]struct {
+		stream      MathableStream[int]
+		wantCount   int
+		wantSum     int
+		wantMin     int
+		wantMax     int
+		wantMean    float64
+		wantVar     float64
+		wantPanic   error
+		concurrency int
+	}{
+		"Should panic for a Stream of nil": {
+			stream:    MathableStream[int]{},
+			wantPanic: ErrMissingChannel,
+		},
+		"Should return an empty summary for an empty Stream": {
+			stream: MathableStream[int]{NewStreamFromSlice([]int{}, 0)},
+		},
+		"Should return the summary with a float64 mean": {
+			stream:    MathableStream[int]{NewStreamFromSlice([]int{1, 2}, 0)},
+			wantCount: 2,
+			wantSum:   3,
+			wantMin:   1,
+			wantMax:   2,
+			wantMean:  1.5,
+			wantVar:   0.25,
+		},
+		"Should return the summary": {
+			stream:    MathableStream[int]{NewStreamFromSlice([]int{2, 4, 4, 4, 5, 5, 7, 9}, 0)},
+			wantCount: 8,
+			wantSum:   40,
+			wantMin:   2,
+			wantMax:   9,
+			wantMean:  5,
+			wantVar:   4,
+		},
+		"Should return the summary of a concurrent Stream": {
+			stream:    MathableStream[int]{NewStreamFromSlice([]int{2, 4, 4, 4, 5, 5, 7, 9}, 0).Concurrent(3)},
+			wantCount: 8,
+			wantSum:   40,
+			wantMin:   2,
+			wantMax:   9,
+			wantMean:  5,
+			wantVar:   4,
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			if tc.wantPanic != nil {
+				assert.PanicsWithValue(t, tc.wantPanic, func() { tc.stream.Stats() })
+				return
+			}
+
+			got := tc.stream.Stats()
+			assert.Equal(t, tc.wantCount, got.Count)
+			assert.Equal(t, tc.wantSum, got.Sum)
+			assert.Equal(t, tc.wantMin, got.Min)
+			assert.Equal(t, tc.wantMax, got.Max)
+			assert.InDelta(t, tc.wantMean, got.Mean, 1e-9)
+			assert.InDelta(t, tc.wantVar, got.Variance(), 1e-9)
+			assert.InDelta(t, math.Sqrt(tc.wantVar), got.StdDev(), 1e-9)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package fuego
+
+import (
+	"expvar"
+	"sync"
+	"time"
+)
+
+// Metrics records the activity of the named stages of streams.
+//
+// Metrics are attached to a Stream with Stream.WithMetrics and are inherited by the
+// Streams derived from it. Only the stages named with Stream.Named are recorded.
+//
+// The methods of Metrics are called concurrently and must therefore be safe for
+// concurrent use.
+type Metrics interface {
+	// In records that the stage received an element.
+	In(stage string)
+
+	// Out records that the stage emitted an element.
+	Out(stage string)
+
+	// Dropped records that the stage discarded an element.
+	Dropped(stage string)
+
+	// UserFunction records the time spent by the stage in a user supplied function
+	// (e.g. the mapper of Stream.Map) for a single element.
+	UserFunction(stage string, d time.Duration)
+
+	// Sent records that the stage sent an element to its out-channel.
+	// length and capacity are those of the channel before the element was sent
+	// and blocked is the time the stage waited to send the element.
+	Sent(stage string, length, capacity int, blocked time.Duration)
+}
+
+// ExpvarMetrics is an implementation of Metrics based on package expvar.
+//
+// The metrics are exposed in JSON, indexed by stage name, with the following counters:
+//   - in, out, dropped: numbers of elements
+//   - user_function_ns: total time spent in user functions, in nanoseconds
+//   - sent: number of elements sent to the out-channel
+//   - channel_len_total: sum of the lengths of the out-channel before each send,
+//     i.e. channel_len_total / sent is the average occupancy of the channel
+//   - channel_cap: capacity of the out-channel
+//   - blocked_send_ns: total time spent waiting to send elements, in nanoseconds
+//
+// Stages that do not use a channel only have the first four counters. This is the case
+// of the stages of synchronous streams (see Stream.Async) and of the stages fused with
+// the next stage (see fusion.go).
+type ExpvarMetrics struct {
+	mu     sync.Mutex
+	stages *expvar.Map
+}
+
+var _ expvar.Var = (*ExpvarMetrics)(nil)
+
+// NewExpvarMetrics creates a new ExpvarMetrics and publishes it with expvar under the
+// given name, unless name is empty.
+//
+// As with expvar.Publish, this function panics if the name is already in use.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	m := &ExpvarMetrics{
+		stages: new(expvar.Map).Init(),
+	}
+
+	if name != "" {
+		expvar.Publish(name, m)
+	}
+
+	return m
+}
+
+// String returns the metrics in JSON.
+func (m *ExpvarMetrics) String() string {
+	return m.stages.String()
+}
+
+// In implements Metrics.
+func (m *ExpvarMetrics) In(stage string) {
+	m.stage(stage).Add("in", 1)
+}
+
+// Out implements Metrics.
+func (m *ExpvarMetrics) Out(stage string) {
+	m.stage(stage).Add("out", 1)
+}
+
+// Dropped implements Metrics.
+func (m *ExpvarMetrics) Dropped(stage string) {
+	m.stage(stage).Add("dropped", 1)
+}
+
+// UserFunction implements Metrics.
+func (m *ExpvarMetrics) UserFunction(stage string, d time.Duration) {
+	m.stage(stage).Add("user_function_ns", int64(d))
+}
+
+// Sent implements Metrics.
+func (m *ExpvarMetrics) Sent(stage string, length, capacity int, blocked time.Duration) {
+	sm := m.stage(stage)
+
+	sm.Add("sent", 1)
+	sm.Add("channel_len_total", int64(length))
+	sm.Add("blocked_send_ns", int64(blocked))
+
+	if sm.Get("channel_cap") == nil {
+		c := new(expvar.Int)
+		c.Set(int64(capacity))
+		sm.Set("channel_cap", c)
+	}
+}
+
+// stage returns the metrics of the named stage.
+func (m *ExpvarMetrics) stage(name string) *expvar.Map {
+	if sm := m.stages.Get(name); sm != nil {
+		return sm.(*expvar.Map)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if sm := m.stages.Get(name); sm != nil {
+		return sm.(*expvar.Map)
+	}
+
+	sm := new(expvar.Map).Init()
+	m.stages.Set(name, sm)
+
+	return sm
+}
+
+// WithMetrics attaches Metrics to this Stream.
+//
+// The Metrics are inherited by the Streams derived from this Stream. Only the
+// stages named with Named are recorded.
+func (s Stream[T]) WithMetrics(m Metrics) Stream[T] {
+	s.metrics = m
+	return s
+}
+
+// Named names the next stage created from this Stream, e.g.
+//
+//	s.Named("evens").Filter(isEven)
+//
+// The name identifies the stage in the Metrics attached to the stream (see WithMetrics).
+// Metrics are recorded by the stages of methods Filter, Map, FlatMap, MapUnordered,
+// FlatMapUnordered, MapWithRetry, Peek, Distinct, TakeWhile, DropWhile and ForEach and
+// their variants (e.g. Take and Drop). The name is also reported to Observers.
+//
+// Unlike the level of concurrency, the name is not inherited by the Streams derived
+// from this Stream.
+func (s Stream[T]) Named(name string) Stream[T] {
+	s.name = name
+	return s
+}
+
+// Name returns the name of the next stage created from this Stream. See Named.
+func (s Stream[T]) Name() string {
+	return s.name
+}
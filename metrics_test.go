@@ -0,0 +1,138 @@
+package fuego
+
+import (
+	"encoding/json"
+	"expvar"
+	"hash/crc32"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// metricsOf returns the metrics of all the stages recorded by m.
+func metricsOf(t *testing.T, m *ExpvarMetrics) map[string]map[string]int64 {
+	got := map[string]map[string]int64{}
+	require.NoError(t, json.Unmarshal([]byte(m.String()), &got))
+
+	return got
+}
+
+func TestNewExpvarMetrics(t *testing.T) {
+	// expvar names cannot be reused: find one that was not published by a previous run (see -count).
+	name := "fuego_test_metrics"
+	for i := 1; expvar.Get(name) != nil; i++ {
+		name = "fuego_test_metrics_" + strconv.Itoa(i)
+	}
+
+	m := NewExpvarMetrics(name)
+	assert.Equal(t, m, expvar.Get(name))
+	assert.Panics(t, func() { NewExpvarMetrics(name) })
+}
+
+func TestExpvarMetrics(t *testing.T) {
+	m := NewExpvarMetrics("")
+
+	m.In("s1")
+	m.In("s1")
+	m.Out("s1")
+	m.Dropped("s1")
+	m.UserFunction("s1", 2*time.Nanosecond)
+	m.UserFunction("s1", 3*time.Nanosecond)
+	m.Sent("s2", 1, 4, 5*time.Nanosecond)
+	m.Sent("s2", 3, 4, 0)
+
+	assert.Equal(t, map[string]map[string]int64{
+		"s1": {"in": 2, "out": 1, "dropped": 1, "user_function_ns": 5},
+		"s2": {"sent": 2, "channel_len_total": 4, "channel_cap": 4, "blocked_send_ns": 5},
+	}, metricsOf(t, m))
+}
+
+func TestStream_Named(t *testing.T) {
+	s := NewStreamFromSlice([]int{1, 2}, 0).Named("stage")
+	assert.Equal(t, "stage", s.Name())
+	assert.Equal(t, "stage", s.Concurrent(2).Name())
+	assert.Equal(t, "", s.Filter(True[int]()).Name())
+}
+
+func TestStream_WithMetrics(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	tt := map[string]struct {
+		stage func(Stream[int]) Stream[int]
+		want  map[string]int64
+	}{
+		"Should record a synchronous Filter": {
+			stage: func(s Stream[int]) Stream[int] { return s.Filter(isEven) },
+			want:  map[string]int64{"in": 10, "out": 5, "dropped": 5},
+		},
+		"Should record a fused Filter": {
+			// the channel of the stage is replaced by that of the next stage it is fused with.
+			stage: func(s Stream[int]) Stream[int] { return s.Async().Filter(isEven) },
+			want:  map[string]int64{"in": 10, "out": 5, "dropped": 5},
+		},
+		"Should record a concurrent Filter": {
+			stage: func(s Stream[int]) Stream[int] { return s.Concurrent(3).Filter(isEven) },
+			want:  map[string]int64{"in": 10, "out": 5, "dropped": 5, "sent": 5, "channel_cap": 0},
+		},
+		"Should record a concurrent Map": {
+			stage: func(s Stream[int]) Stream[int] { return Map(s.Concurrent(3), Identity[int]) },
+			want:  map[string]int64{"in": 10, "out": 10, "sent": 10, "channel_cap": 0},
+		},
+		"Should record a MapByKey": {
+			stage: func(s Stream[int]) Stream[int] {
+				return C(s.MapByKey(func(i int) Any { return i % 3 }, 2, func(i int) Any { return i }), Int)
+			},
+			want: map[string]int64{"in": 10, "out": 10, "sent": 10, "channel_cap": 0},
+		},
+		"Should record a DropWhile": {
+			stage: func(s Stream[int]) Stream[int] { return s.Drop(3) },
+			want:  map[string]int64{"in": 10, "out": 7, "dropped": 3},
+		},
+		"Should record a Distinct": {
+			stage: func(s Stream[int]) Stream[int] {
+				return s.Distinct(func(i int) uint32 { return crc32.ChecksumIEEE([]byte(strconv.Itoa(i % 4))) })
+			},
+			want: map[string]int64{"in": 10, "out": 4, "dropped": 6, "sent": 4, "channel_cap": 0},
+		},
+		"Should record a FlatMap": {
+			stage: func(s Stream[int]) Stream[int] {
+				return FlatMap(s, func(i int) Stream[int] { return NewStreamFromSlice([]int{i, i}, 0) })
+			},
+			want: map[string]int64{"in": 10, "out": 20, "sent": 20, "channel_cap": 0},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			m := NewExpvarMetrics("")
+
+			s := NewStreamFromSlice(intRange(10), 0).WithMetrics(m).Named("stage")
+			got := tc.stage(s).
+				Filter(True[int]()). // this stage is not named and hence not recorded.
+				Count()
+
+			assert.Equal(t, int(tc.want["out"]), got)
+
+			metrics := metricsOf(t, m)
+			assert.Len(t, metrics, 1)
+
+			// times are not deterministic.
+			assert.Contains(t, metrics["stage"], "user_function_ns")
+			delete(metrics["stage"], "user_function_ns")
+			delete(metrics["stage"], "blocked_send_ns")
+			delete(metrics["stage"], "channel_len_total")
+
+			if _, ok := tc.want["sent"]; !ok && DisableFusion {
+				// every stage has its own channel.
+				tc.want["sent"], tc.want["channel_cap"] = tc.want["out"], 0
+			}
+
+			assert.Equal(t, tc.want, metrics["stage"])
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package fuego
+
+import "sync/atomic"
+
+// StageInfo describes a stage of a Stream.
+type StageInfo struct {
+	// Operation is the name of the method that created the stage, e.g. "Filter".
+	Operation string
+
+	// Name is the name of the stage, as given with Stream.Named. It may be empty.
+	Name string
+}
+
+// Observer is notified of the lifecycle of the stages of streams and of the
+// elements they process. This is intended for tracing, auditing, logging, etc.
+//
+// Observers are attached to a Stream with Stream.WithObserver or globally with
+// SetObserver. They are notified by the stages of methods Filter, Map, FlatMap,
+// MapUnordered, FlatMapUnordered, MapWithRetry, Peek, Distinct, TakeWhile, DropWhile
+// and ForEach and their variants (e.g. Take and Drop).
+//
+// The methods of Observer are called concurrently and must therefore be safe for
+// concurrent use. They are called synchronously by the stages: slow Observers slow
+// down the streams.
+type Observer interface {
+	// OnStageStart is called once, when the stage starts processing elements.
+	OnStageStart(stage StageInfo)
+
+	// OnElement is called for each element received by the stage.
+	OnElement(stage StageInfo, element any)
+
+	// OnStageClose is called once, when the stage has emitted all its elements.
+	// It is not called for the stages whose elements are not all consumed
+	// (e.g. upstream of Take).
+	OnStageClose(stage StageInfo)
+
+	// OnPanic is called when a user supplied function of the stage panics. The
+	// panic is then resumed.
+	OnPanic(stage StageInfo, recovered any)
+
+	// OnError is called when a user supplied function of the stage returns an error
+	// (e.g. the mapper of Stream.MapWithRetry).
+	OnError(stage StageInfo, err error)
+}
+
+// ObserverFuncs is an Observer made of optional functions: the notifications for
+// which the function is nil are ignored.
+type ObserverFuncs struct {
+	StageStart func(stage StageInfo)
+	Element    func(stage StageInfo, element any)
+	StageClose func(stage StageInfo)
+	Panic      func(stage StageInfo, recovered any)
+	Error      func(stage StageInfo, err error)
+}
+
+var _ Observer = ObserverFuncs{}
+
+// OnStageStart implements Observer.
+func (o ObserverFuncs) OnStageStart(stage StageInfo) {
+	if o.StageStart != nil {
+		o.StageStart(stage)
+	}
+}
+
+// OnElement implements Observer.
+func (o ObserverFuncs) OnElement(stage StageInfo, element any) {
+	if o.Element != nil {
+		o.Element(stage, element)
+	}
+}
+
+// OnStageClose implements Observer.
+func (o ObserverFuncs) OnStageClose(stage StageInfo) {
+	if o.StageClose != nil {
+		o.StageClose(stage)
+	}
+}
+
+// OnPanic implements Observer.
+func (o ObserverFuncs) OnPanic(stage StageInfo, recovered any) {
+	if o.Panic != nil {
+		o.Panic(stage, recovered)
+	}
+}
+
+// OnError implements Observer.
+func (o ObserverFuncs) OnError(stage StageInfo, err error) {
+	if o.Error != nil {
+		o.Error(stage, err)
+	}
+}
+
+// WithObserver attaches an Observer to this Stream. It takes precedence over the
+// global Observer (see SetObserver).
+//
+// The Observer is inherited by the Streams derived from this Stream.
+func (s Stream[T]) WithObserver(o Observer) Stream[T] {
+	s.observer = o
+	return s
+}
+
+// observerHolder allows to store a nil Observer in an atomic.Value.
+type observerHolder struct {
+	observer Observer
+}
+
+var globalObserverValue atomic.Value // nolint: gochecknoglobals
+
+// SetObserver sets the global Observer, i.e. the Observer of the Streams that do not
+// have one attached with Stream.WithObserver. nil removes the global Observer.
+//
+// The global Observer is read when the stages are created: it has no effect on the
+// stages created before it was set.
+func SetObserver(o Observer) {
+	globalObserverValue.Store(observerHolder{observer: o})
+}
+
+// globalObserver returns the global Observer, if any.
+func globalObserver() Observer {
+	if h, ok := globalObserverValue.Load().(observerHolder); ok {
+		return h.observer
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package fuego
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingObserver records the notifications it receives as strings.
+type recordingObserver struct {
+	mu     sync.Mutex
+	events map[string][]string
+}
+
+func newRecordingObserver() *recordingObserver {
+	return &recordingObserver{events: map[string][]string{}}
+}
+
+func (o *recordingObserver) record(stage StageInfo, event string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	key := stage.Operation
+	if stage.Name != "" {
+		key += "/" + stage.Name
+	}
+
+	o.events[key] = append(o.events[key], event)
+}
+
+func (o *recordingObserver) observer() Observer {
+	return ObserverFuncs{
+		StageStart: func(stage StageInfo) { o.record(stage, "start") },
+		Element:    func(stage StageInfo, element any) { o.record(stage, fmt.Sprint(element)) },
+		StageClose: func(stage StageInfo) { o.record(stage, "close") },
+		Panic:      func(stage StageInfo, recovered any) { o.record(stage, fmt.Sprint("panic: ", recovered)) },
+		Error:      func(stage StageInfo, err error) { o.record(stage, "error: "+err.Error()) },
+	}
+}
+
+func TestStream_WithObserver(t *testing.T) {
+	isOdd := func(i int) bool { return i%2 == 1 }
+
+	tt := map[string]struct {
+		stream Stream[int]
+	}{
+		"Should observe synchronous stages": {
+			stream: NewStreamFromSlice([]int{1, 2, 3}, 0),
+		},
+		"Should observe fused stages": {
+			stream: NewStreamFromSlice([]int{1, 2, 3}, 0).Async(),
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			o := newRecordingObserver()
+
+			s := tc.stream.WithObserver(o.observer()).Named("odd").Filter(isOdd)
+			Map(s, func(i int) int { return i * 10 }).ForEach(func(int) {})
+
+			assert.Equal(t, map[string][]string{
+				"Filter/odd": {"start", "1", "2", "3", "close"},
+				"Map":        {"start", "1", "3", "close"},
+				"ForEach":    {"start", "10", "30", "close"},
+			}, o.events)
+		})
+	}
+}
+
+func TestStream_WithObserver_Concurrent(t *testing.T) {
+	o := newRecordingObserver()
+
+	s := NewStreamFromSlice([]int{1, 2, 3}, 0).WithObserver(o.observer()).Concurrent(2)
+	got := Map(s, func(i int) int { return i * 10 }).ToSlice()
+
+	assert.Equal(t, []int{10, 20, 30}, got)
+	assert.Equal(t, "start", o.events["Map"][0])
+	assert.ElementsMatch(t, []string{"1", "2", "3"}, o.events["Map"][1:4])
+	assert.Equal(t, "close", o.events["Map"][4])
+}
+
+func TestStream_WithObserver_Panic(t *testing.T) {
+	if DisableFusion {
+		t.Skip("the panic cannot be recovered when the stage runs in its own Go routine")
+	}
+
+	o := newRecordingObserver()
+
+	s := NewStreamFromSlice([]int{1, 2}, 0).WithObserver(o.observer())
+
+	assert.PanicsWithValue(t, "boom", func() {
+		Map(s, func(i int) int {
+			if i == 2 {
+				panic("boom")
+			}
+			return i
+		}).ToSlice()
+	})
+
+	assert.Equal(t, []string{"start", "1", "2", "panic: boom"}, o.events["Map"])
+}
+
+func TestStream_WithObserver_Error(t *testing.T) {
+	o := newRecordingObserver()
+	errTransient := errors.New("transient")
+
+	got := C(NewStreamFromSlice([]int{1}, 0).
+		WithObserver(o.observer()).
+		MapWithRetry(RetryPolicy[int]{MaxAttempts: 3}, failingTimesTwo(map[int]int{1: 2}, errTransient)), Int).
+		ToSlice()
+
+	assert.Equal(t, []int{2}, got)
+	assert.Equal(t, []string{"start", "1", "error: transient", "error: transient", "close"}, o.events["MapWithRetry"])
+}
+
+func TestSetObserver(t *testing.T) {
+	global, local := newRecordingObserver(), newRecordingObserver()
+
+	SetObserver(global.observer())
+	defer SetObserver(nil)
+
+	NewStreamFromSlice([]int{1}, 0).ForEach(func(int) {})
+	NewStreamFromSlice([]int{2}, 0).WithObserver(local.observer()).ForEach(func(int) {})
+
+	SetObserver(nil)
+	NewStreamFromSlice([]int{3}, 0).ForEach(func(int) {})
+
+	assert.Equal(t, map[string][]string{"ForEach": {"start", "1", "close"}}, global.events)
+	assert.Equal(t, map[string][]string{"ForEach": {"start", "2", "close"}}, local.events)
+}
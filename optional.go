@@ -41,13 +41,23 @@ func (o Optional[T]) IfPresent(c Consumer[T]) {
 	}
 }
 
-// Get returns the value if present, otherwise panics.
+// Get returns the value if present, otherwise panics with ErrNoSuchElement.
+// See TryGet for a variant that returns an error instead.
 func (o Optional[T]) Get() T {
 	if o.present {
 		return o.value
 	}
 
-	panic(PanicNoSuchElement)
+	panic(ErrNoSuchElement)
+}
+
+// TryGet returns the value if present, otherwise ErrNoSuchElement.
+func (o Optional[T]) TryGet() (T, error) {
+	if o.present {
+		return o.value, nil
+	}
+
+	return o.value, ErrNoSuchElement
 }
 
 // Or returns an Optional describing the value if present, otherwise returns an Optional produced by the
@@ -57,6 +57,20 @@ func TestOptional_Get_Present(t *testing.T) {
 	assert.Equal(t, 123, got)
 }
 
+func TestOptional_Get_Empty(t *testing.T) {
+	assert.PanicsWithValue(t, ErrNoSuchElement, func() { OptionalEmpty[int]().Get() })
+}
+
+func TestOptional_TryGet(t *testing.T) {
+	got, err := OptionalOf(7).TryGet()
+	assert.NoError(t, err)
+	assert.Equal(t, 7, got)
+
+	got, err = OptionalEmpty[int]().TryGet()
+	assert.ErrorIs(t, err, ErrNoSuchElement)
+	assert.Equal(t, 0, got)
+}
+
 func TestOptional_Or_NotEmpty(t *testing.T) {
 	got := OptionalOf(123).Or(func() Optional[int] { return OptionalOf(456) })
 	assert.Equal(t, OptionalOf(123), got)
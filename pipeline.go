@@ -0,0 +1,139 @@
+package fuego
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pipeline is a reusable description of a series of stages that transform a Stream[T]
+// into a Stream[R].
+//
+// Building a Pipeline does not start any Go routine: the stages are only instantiated
+// when the Pipeline is Run on a Stream. A Pipeline can therefore be declared once (e.g. as
+// a package-level value), tested in isolation and run on any number of Streams.
+//
+// A Pipeline is immutable: all its methods return a new Pipeline.
+//
+// Stages that change the type of the elements are added with functions (rather than
+// methods) such as ThenMap, ThenFlatMap and Compose since Go does not support
+// parameterised methods. See doc.go.
+type Pipeline[T, R any] struct {
+	names []string
+	stage Stage[T, R]
+}
+
+// NewPipeline creates an empty Pipeline, i.e. one that returns its input Stream unchanged.
+func NewPipeline[T any]() Pipeline[T, T] {
+	return Pipeline[T, T]{
+		names: []string{},
+		stage: Identity[Stream[T]],
+	}
+}
+
+// NewStagePipeline creates a Pipeline made of a single named Stage.
+func NewStagePipeline[T, R any](name string, stage Stage[T, R]) Pipeline[T, R] {
+	return Pipeline[T, R]{
+		names: []string{name},
+		stage: stage,
+	}
+}
+
+// Run applies the stages of this Pipeline to the Stream.
+func (p Pipeline[T, R]) Run(s Stream[T]) Stream[R] {
+	return p.stage(s)
+}
+
+// Stages returns the names of the stages of this Pipeline, in order of execution.
+func (p Pipeline[T, R]) Stages() []string {
+	return append([]string{}, p.names...)
+}
+
+// String returns a textual representation of this Pipeline for diagnostic purposes.
+func (p Pipeline[T, R]) String() string {
+	return fmt.Sprintf("Pipeline[%s -> %s]: %s", typeName[T](), typeName[R](), strings.Join(p.names, " -> "))
+}
+
+// typeName returns the name of type T.
+func typeName[T any]() string {
+	return fmt.Sprintf("%T", new(T))[1:] // new(T) is used since a nil interface has no type
+}
+
+// Then returns a new Pipeline that applies the stages of this Pipeline followed by the
+// stages of Pipeline q.
+//
+// See Compose when q changes the type of the elements.
+func (p Pipeline[T, R]) Then(q Pipeline[R, R]) Pipeline[T, R] {
+	return Compose(p, q)
+}
+
+// Stage returns a new Pipeline that applies the stages of this Pipeline followed by the
+// given named stage.
+func (p Pipeline[T, R]) Stage(name string, stage Stage[R, R]) Pipeline[T, R] {
+	return p.Then(NewStagePipeline(name, stage))
+}
+
+// Concurrent returns a new Pipeline that sets the level of concurrency of the Stream
+// at this point of this Pipeline.
+//
+// See Stream.Concurrent.
+func (p Pipeline[T, R]) Concurrent(n int) Pipeline[T, R] {
+	return p.Stage(fmt.Sprintf("Concurrent(%d)", n), func(s Stream[R]) Stream[R] {
+		return s.Concurrent(n)
+	})
+}
+
+// Filter returns a new Pipeline with an additional Stream.Filter stage.
+func (p Pipeline[T, R]) Filter(predicate Predicate[R]) Pipeline[T, R] {
+	return p.Stage("Filter", func(s Stream[R]) Stream[R] {
+		return s.Filter(predicate)
+	})
+}
+
+// Peek returns a new Pipeline with an additional Stream.Peek stage.
+func (p Pipeline[T, R]) Peek(consumer Consumer[R]) Pipeline[T, R] {
+	return p.Stage("Peek", func(s Stream[R]) Stream[R] {
+		return s.Peek(consumer)
+	})
+}
+
+// Distinct returns a new Pipeline with an additional Stream.Distinct stage.
+func (p Pipeline[T, R]) Distinct(hashFn func(R) uint32) Pipeline[T, R] {
+	return p.Stage("Distinct", func(s Stream[R]) Stream[R] {
+		return s.Distinct(hashFn)
+	})
+}
+
+// Take returns a new Pipeline with an additional Stream.Take stage.
+func (p Pipeline[T, R]) Take(n uint64) Pipeline[T, R] {
+	return p.Stage(fmt.Sprintf("Take(%d)", n), func(s Stream[R]) Stream[R] {
+		return s.Take(n)
+	})
+}
+
+// Drop returns a new Pipeline with an additional Stream.Drop stage.
+func (p Pipeline[T, R]) Drop(n uint64) Pipeline[T, R] {
+	return p.Stage(fmt.Sprintf("Drop(%d)", n), func(s Stream[R]) Stream[R] {
+		return s.Drop(n)
+	})
+}
+
+// Compose returns a new Pipeline that applies the stages of Pipeline p followed by the
+// stages of Pipeline q.
+func Compose[T, U, R any](p Pipeline[T, U], q Pipeline[U, R]) Pipeline[T, R] {
+	return Pipeline[T, R]{
+		names: append(p.Stages(), q.names...),
+		stage: func(s Stream[T]) Stream[R] {
+			return q.stage(p.stage(s))
+		},
+	}
+}
+
+// ThenMap returns a new Pipeline that applies the stages of Pipeline p followed by Map.
+func ThenMap[T, U, R any](p Pipeline[T, U], mapper Function[U, R]) Pipeline[T, R] {
+	return Compose(p, NewStagePipeline("Map", MapStage(mapper)))
+}
+
+// ThenFlatMap returns a new Pipeline that applies the stages of Pipeline p followed by FlatMap.
+func ThenFlatMap[T, U, R any](p Pipeline[T, U], mapper StreamFunction[U, R]) Pipeline[T, R] {
+	return Compose(p, NewStagePipeline("FlatMap", FlatMapStage(mapper)))
+}
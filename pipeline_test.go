@@ -0,0 +1,103 @@
+package fuego
+
+import (
+	"hash/crc32"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// wordLengths is a package-level Pipeline, as users of fuego would declare it.
+var wordLengths = ThenMap(
+	NewPipeline[string]().
+		Filter(func(s string) bool { return s != "" }).
+		Distinct(func(s string) uint32 { return crc32.ChecksumIEEE([]byte(s)) }),
+	func(s string) int { return len(s) },
+)
+
+func TestPipeline_Run(t *testing.T) {
+	tt := map[string]struct {
+		stream Stream[string]
+		want   []int
+	}{
+		"Should return an empty stream when nil in-stream": {
+			stream: Stream[string]{stream: nil},
+			want:   []int{},
+		},
+		"Should apply all stages": {
+			stream: NewStreamFromSlice([]string{"a", "", "bb", "a", "ccc", "bb"}, 0),
+			want:   []int{1, 2, 3},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := wordLengths.Run(tc.stream).ToSlice()
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPipeline_IsReusable(t *testing.T) {
+	p := NewPipeline[int]().Drop(1).Take(2)
+
+	assert.Equal(t, []int{2, 3}, p.Run(NewStreamFromSlice([]int{1, 2, 3, 4}, 0)).ToSlice())
+	assert.Equal(t, []int{6, 7}, p.Run(NewStreamFromSlice([]int{5, 6, 7, 8}, 0)).ToSlice())
+}
+
+func TestPipeline_Empty(t *testing.T) {
+	p := NewPipeline[int]()
+
+	assert.Equal(t, []int{1, 2}, p.Run(NewStreamFromSlice([]int{1, 2}, 0)).ToSlice())
+	assert.Equal(t, []string{}, p.Stages())
+}
+
+func TestPipeline_Then(t *testing.T) {
+	evens := NewPipeline[int]().Filter(func(i int) bool { return i%2 == 0 })
+	firstTwo := NewPipeline[int]().Take(2)
+
+	p := evens.Then(firstTwo)
+
+	assert.Equal(t, []int{2, 4}, p.Run(NewStreamFromSlice([]int{1, 2, 3, 4, 5, 6}, 0)).ToSlice())
+	assert.Equal(t, []string{"Filter", "Take(2)"}, p.Stages())
+
+	// p's components are not altered
+	assert.Equal(t, []string{"Filter"}, evens.Stages())
+	assert.Equal(t, []string{"Take(2)"}, firstTwo.Stages())
+}
+
+func TestPipeline_Concurrent_Peek(t *testing.T) {
+	peeked := []int{}
+
+	p := NewPipeline[int]().
+		Peek(func(i int) { peeked = append(peeked, i) }).
+		Concurrent(3)
+
+	got := p.Run(NewStreamFromSlice([]int{1, 2, 3}, 0))
+	assert.Equal(t, 3, got.Concurrency())
+	assert.Equal(t, []int{1, 2, 3}, got.ToSlice())
+	assert.Equal(t, []int{1, 2, 3}, peeked)
+	assert.Equal(t, []string{"Peek", "Concurrent(3)"}, p.Stages())
+}
+
+func TestCompose(t *testing.T) {
+	toString := ThenMap(NewPipeline[int](), strconv.Itoa)
+	explode := ThenFlatMap(NewPipeline[string](), func(s string) Stream[rune] {
+		return NewStreamFromSlice([]rune(s), 0)
+	})
+
+	p := Compose(toString, explode)
+
+	got := p.Run(NewStreamFromSlice([]int{12, 345}, 0)).ToSlice()
+	assert.Equal(t, []rune{'1', '2', '3', '4', '5'}, got)
+	assert.Equal(t, []string{"Map", "FlatMap"}, p.Stages())
+}
+
+func TestPipeline_String(t *testing.T) {
+	assert.Equal(t, "Pipeline[string -> int]: Filter -> Distinct -> Map", wordLengths.String())
+	assert.Equal(t, "Pipeline[int -> int]: ", NewPipeline[int]().String())
+	assert.Equal(t, "Pipeline[int -> fuego.Any]: Stage", NewStagePipeline("Stage", Stage[int, Any](Stream[int].StreamAny)).String())
+}
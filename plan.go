@@ -0,0 +1,211 @@
+package fuego
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PlanNode describes a stage of a Stream and, through its inputs, the stages
+// it was composed from. Together, they form a directed acyclic graph: a stage
+// may have several inputs (e.g. HashJoin) and several stages may share the
+// same input (e.g. the streams returned by Tee).
+//
+// See Stream.Describe.
+type PlanNode struct {
+	// Operation is the name of the function or method that created the stage,
+	// e.g. "Filter" or "NewStreamFromSlice".
+	Operation string
+
+	// Name is the name of the stage, as given with Stream.Named. It may be empty.
+	Name string
+
+	// Concurrency is the level of concurrency of the stream at the stage.
+	Concurrency int
+
+	// BufferSize is the capacity of the out-channel of the stage.
+	// It is 0 for synchronous stages.
+	BufferSize int
+
+	// Synchronous is true when the stage is executed as function calls, without
+	// Go routine nor channel. See Stream.Async.
+	Synchronous bool
+
+	// Fused is true when the stage runs in a Go routine it may share with the adjacent
+	// stages. See fusion.go.
+	Fused bool
+
+	// Inputs are the stages this stage consumes. Sources have none.
+	Inputs []*PlanNode
+}
+
+// Describe returns the description of the stages this Stream was composed from,
+// its last stage first.
+//
+// The description is built as the stages are created. Streams not created with
+// the functions of this package are described as a single "Stream" stage.
+func (s Stream[T]) Describe() *PlanNode {
+	if s.plan == nil {
+		return s.planned("Stream", "").plan
+	}
+
+	return s.plan
+}
+
+// planned returns this Stream with a new stage of the plan that has the given operation
+// and name, and that consumes the given input stages.
+func (s Stream[T]) planned(operation, name string, inputs ...*PlanNode) Stream[T] {
+	bufsize := 0
+	if s.stream != nil {
+		bufsize = cap(s.stream)
+	}
+
+	s.plan = &PlanNode{
+		Operation:   operation,
+		Name:        name,
+		Concurrency: s.concurrency,
+		BufferSize:  bufsize,
+		Synchronous: s.pull != nil,
+		Fused:       s.fused != nil,
+		Inputs:      inputs,
+	}
+
+	return s
+}
+
+// planStage returns Stream out with a new stage of the plan that has the given operation
+// and that consumes Stream s.
+func planStage[T, U any](s Stream[T], out Stream[U], operation string) Stream[U] {
+	return out.planned(operation, s.name, s.Describe())
+}
+
+// String returns a human-readable, indented text plan of the stage and its inputs,
+// akin to an SQL EXPLAIN, e.g.:
+//
+//	-> Map (buffer=10)
+//	    -> Filter "evens" (synchronous)
+//	        -> NewStreamFromSlice (synchronous)
+//
+// Stages that are the input of several stages are only described in full once.
+func (n *PlanNode) String() string {
+	sb := &strings.Builder{}
+	ids := n.ids()
+	seen := map[*PlanNode]bool{}
+
+	consumers := map[*PlanNode]int{}
+	n.walk(func(node *PlanNode) {
+		for _, input := range node.Inputs {
+			consumers[input]++
+		}
+	})
+
+	var write func(node *PlanNode, depth int)
+	write = func(node *PlanNode, depth int) {
+		sb.WriteString(strings.Repeat("    ", depth))
+		sb.WriteString("-> ")
+		sb.WriteString(node.label())
+
+		if seen[node] {
+			fmt.Fprintf(sb, " [see #%d]\n", ids[node])
+			return
+		}
+
+		seen[node] = true
+
+		if consumers[node] > 1 {
+			fmt.Fprintf(sb, " #%d", ids[node])
+		}
+
+		sb.WriteString("\n")
+
+		for _, input := range node.Inputs {
+			write(input, depth+1)
+		}
+	}
+
+	write(n, 0)
+
+	return sb.String()
+}
+
+// DOT returns the description of the stage and its inputs in the Graphviz DOT language.
+// The edges go from the inputs to the stages that consume them.
+func (n *PlanNode) DOT() string {
+	sb := &strings.Builder{}
+	sb.WriteString("digraph fuego {\n")
+	sb.WriteString("\trankdir=LR;\n")
+
+	ids := n.ids()
+
+	n.walk(func(node *PlanNode) {
+		fmt.Fprintf(sb, "\tn%d [label=%s];\n", ids[node], strconv.Quote(node.label()))
+	})
+
+	n.walk(func(node *PlanNode) {
+		for _, input := range node.Inputs {
+			fmt.Fprintf(sb, "\tn%d -> n%d;\n", ids[input], ids[node])
+		}
+	})
+
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// label describes the stage on a single line.
+func (n *PlanNode) label() string {
+	label := n.Operation
+	if n.Name != "" {
+		label += " " + strconv.Quote(n.Name)
+	}
+
+	props := []string{}
+
+	if n.Synchronous {
+		props = append(props, "synchronous")
+	} else {
+		props = append(props, "buffer="+strconv.Itoa(n.BufferSize))
+	}
+
+	if n.Concurrency > 0 {
+		props = append(props, "concurrency="+strconv.Itoa(n.Concurrency))
+	}
+
+	if n.Fused {
+		props = append(props, "fused")
+	}
+
+	return label + " (" + strings.Join(props, ", ") + ")"
+}
+
+// walk calls fn once for each stage of the plan, depth first, the stage first.
+func (n *PlanNode) walk(fn func(*PlanNode)) {
+	seen := map[*PlanNode]bool{}
+
+	var walk func(node *PlanNode)
+	walk = func(node *PlanNode) {
+		if seen[node] {
+			return
+		}
+
+		seen[node] = true
+		fn(node)
+
+		for _, input := range node.Inputs {
+			walk(input)
+		}
+	}
+
+	walk(n)
+}
+
+// ids numbers the stages of the plan, starting at 1, in the order of walk.
+func (n *PlanNode) ids() map[*PlanNode]int {
+	ids := map[*PlanNode]int{}
+
+	n.walk(func(node *PlanNode) {
+		ids[node] = len(ids) + 1
+	})
+
+	return ids
+}
@@ -0,0 +1,105 @@
+package fuego
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream_Describe(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	tt := map[string]struct {
+		stream func() Stream[int]
+		want   string
+	}{
+		"Should describe a Stream not created by fuego": {
+			stream: func() Stream[int] { return Stream[int]{} },
+			want:   "-> Stream (buffer=0)\n",
+		},
+		"Should describe a synchronous stream": {
+			stream: func() Stream[int] {
+				return Map(NewStreamFromSlice([]int{1, 2}, 3).Named("evens").Filter(isEven), Identity[int])
+			},
+			want: "" +
+				"-> Map (synchronous)\n" +
+				"    -> Filter \"evens\" (synchronous)\n" +
+				"        -> NewStreamFromSlice (synchronous)\n",
+		},
+		"Should describe a concurrent stream": {
+			stream: func() Stream[int] {
+				c := make(chan int, 2)
+				close(c)
+
+				return Map(NewStream(c).Concurrent(3), Identity[int])
+			},
+			want: "" +
+				"-> Map (buffer=2, concurrency=3)\n" +
+				"    -> NewStream (buffer=2)\n",
+		},
+		"Should describe a fused stream": {
+			stream: func() Stream[int] {
+				return NewStreamFromSlice([]int{1, 2}, 3).Async().Filter(isEven)
+			},
+			want: "" +
+				"-> Filter (buffer=3, fused)\n" +
+				"    -> Async (buffer=3)\n" +
+				"        -> NewStreamFromSlice (synchronous)\n",
+		},
+		"Should describe shared stages once": {
+			stream: func() Stream[int] {
+				tee := NewStreamFromSlice([]int{1, 2}, 2).Tee(2)
+				return C(HashJoin(tee[0], tee[1], Identity[int], Identity[int]).
+					Map(func(p Pair[int, int]) Any { return p.Left }), Int)
+			},
+			want: "" +
+				"-> C (buffer=0)\n" +
+				"    -> Map (buffer=2, fused)\n" +
+				"        -> HashJoin (buffer=2)\n" +
+				"            -> Tee (buffer=2)\n" +
+				"                -> Async (buffer=2) #5\n" +
+				"                    -> NewStreamFromSlice (synchronous)\n" +
+				"            -> Tee (buffer=2)\n" +
+				"                -> Async (buffer=2) [see #5]\n",
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			if DisableFusion {
+				t.Skip("the stages are not synchronous nor fused when fusion is disabled")
+			}
+
+			s := tc.stream()
+			assert.Equal(t, tc.want, s.Describe().String())
+
+			s.ForEach(func(int) {})
+		})
+	}
+}
+
+func TestPlanNode_DOT(t *testing.T) {
+	source := &PlanNode{Operation: "NewStreamFromSlice", Synchronous: true}
+	plan := &PlanNode{
+		Operation: "HashJoin",
+		Inputs: []*PlanNode{
+			{Operation: "Filter", Name: "evens", Synchronous: true, Inputs: []*PlanNode{source}},
+			source,
+		},
+	}
+
+	want := `digraph fuego {
+	rankdir=LR;
+	n1 [label="HashJoin (buffer=0)"];
+	n2 [label="Filter \"evens\" (synchronous)"];
+	n3 [label="NewStreamFromSlice (synchronous)"];
+	n2 -> n1;
+	n3 -> n1;
+	n3 -> n2;
+}
+`
+
+	assert.Equal(t, want, plan.DOT())
+}
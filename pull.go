@@ -0,0 +1,205 @@
+package fuego
+
+import "sync"
+
+// NOTICE:
+// Synchronous streams.
+//
+// A Stream created from a slice (or from a pull-style iterator) is synchronous: it
+// does not wrap a Go channel but a "pull" function that returns the next element of
+// the stream, or false when the stream is exhausted.
+//
+// Non-concurrent Filter, Map, Peek, Take and Drop stages (and their variants) compose
+// synchronous streams as function calls: no Go routine nor channel is involved and
+// the elements are processed on the Go routine of the terminal operation, one at a time.
+//
+// All the other stages, as well as concurrent stages (see Stream.Concurrent), start
+// with an asynchronous boundary (see Stream.Async): the synchronous stages upstream
+// of the boundary run in a Go routine that feeds a channel.
+//
+// Like a channel, a synchronous stream may be consumed by several Go routines
+// concurrently: the pull functions of the stages derived from a source share a lock
+// (see Stream.next) so that each element is produced once and the state of the stages
+// (e.g. TakeWhile) is never accessed concurrently.
+
+// newPullStream creates a new synchronous Stream over the pull function.
+//
+// bufsize is the capacity of the channel created at the asynchronous boundary,
+// should the stream require one. See Stream.Async.
+func newPullStream[T any](pull func() (T, bool), bufsize int) Stream[T] {
+	return Stream[T]{
+		pull:     pull,
+		pullLock: &sync.Mutex{},
+		bufsize:  bufsize,
+		releaser: newReleaser(),
+		failure:  newFailure(),
+	}
+}
+
+// derivedPullStream creates a new synchronous Stream over the pull function that
+// inherits the settings (concurrency, etc) of Stream s.
+func derivedPullStream[T, U any](s Stream[T], pull func() (U, bool)) Stream[U] {
+	return Stream[U]{
+		pull:        pull,
+		pullLock:    s.pullLock,
+		bufsize:     s.bufsize,
+		concurrency: s.concurrency,
+		batchSize:   s.batchSize,
+		releaser:    s.releaser,
+		failure:     s.failure,
+		metrics:     s.metrics,
+		observer:    s.observer,
+		logger:      s.logger,
+		plan:        s.plan,
+	}
+}
+
+// Async returns a Stream backed by a Go channel that holds the elements of this stream.
+//
+// When this stream is synchronous (e.g. created with NewStreamFromSlice), its stages
+// run in a new Go routine that feeds the channel. The capacity of the channel is the
+// bufsize supplied when the source of the stream was created.
+// This decouples the producer from the consumer, which is beneficial when both have
+// a significant latency.
+//
+// When this stream is already backed by a channel, it is returned unchanged, unless
+// its stages were fused with those of another stream (see fusion.go): they then run
+// in a new Go routine that feeds the channel.
+//
+// The stream settings (concurrency, etc) are preserved.
+func (s Stream[T]) Async() Stream[T] {
+	if s.fused != nil && s.fused.isTakenOver() {
+		as := newFusedStream(s, nil, s.fused.next)
+		as.name = s.name
+
+		return as
+	}
+
+	if s.pull == nil {
+		return s
+	}
+
+	outstream := make(chan T, s.bufsize)
+
+	go func() {
+		defer close(outstream)
+
+		for val, ok := s.next(); ok; val, ok = s.next() {
+			select {
+			case outstream <- val:
+			case <-s.releaser.released():
+				return
+			}
+		}
+	}()
+
+	as := derivedStream(s, outstream).planned("Async", "", s.Describe())
+	as.name = s.name // this is not a stage: the name applies to the next stage.
+
+	return as
+}
+
+// isNil returns true when this stream has neither a channel nor a pull function,
+// i.e. when it was created over a nil channel.
+func (s Stream[T]) isNil() bool {
+	return s.stream == nil && s.pull == nil
+}
+
+// receive reads the next element of this stream.
+// It returns false when the stream is exhausted or when the channel is nil.
+func (s Stream[T]) receive() (T, bool) {
+	if s.pull != nil {
+		return s.next()
+	}
+
+	if s.fused != nil && s.fused.isTakenOver() {
+		return s.fused.next()
+	}
+
+	if s.stream == nil {
+		var t T
+		return t, false
+	}
+
+	val, ok := <-s.stream
+	if !ok {
+		s.failure.resume()
+	}
+
+	return val, ok
+}
+
+// next returns the next element of this synchronous stream, under the lock shared
+// by the Streams derived from its source.
+func (s Stream[T]) next() (T, bool) {
+	s.pullLock.Lock()
+	defer s.pullLock.Unlock()
+
+	return s.pull()
+}
+
+// pullMap returns a pull function that applies the mapper to the elements returned by pull.
+func pullMap[T, U any](pull func() (T, bool), mapper Function[T, U]) func() (U, bool) {
+	return func() (U, bool) {
+		val, ok := pull()
+		if !ok {
+			var u U
+			return u, false
+		}
+
+		return mapper(val), true
+	}
+}
+
+// pullFilter returns a pull function that skips the elements returned by pull
+// that do not satisfy the predicate.
+func pullFilter[T any](pull func() (T, bool), predicate Predicate[T]) func() (T, bool) {
+	return func() (T, bool) {
+		for {
+			val, ok := pull()
+			if !ok || predicate(val) {
+				return val, ok
+			}
+		}
+	}
+}
+
+// pullTakeWhile returns a pull function that returns the elements returned by pull
+// while they satisfy the predicate. Neither pull nor the predicate are called
+// anymore once the predicate is not satisfied.
+func pullTakeWhile[T any](pull func() (T, bool), p Predicate[T]) func() (T, bool) {
+	done := false
+
+	return func() (T, bool) {
+		if !done {
+			val, ok := pull()
+			if ok && p(val) {
+				return val, true
+			}
+
+			done = true
+		}
+
+		var t T
+
+		return t, false
+	}
+}
+
+// pullDropWhile returns a pull function that skips the first elements returned by
+// pull while they satisfy the predicate.
+func pullDropWhile[T any](pull func() (T, bool), p Predicate[T]) func() (T, bool) {
+	dropping := true
+
+	return func() (T, bool) {
+		val, ok := pull()
+
+		for dropping && ok && p(val) {
+			val, ok = pull()
+		}
+
+		dropping = false
+
+		return val, ok
+	}
+}
@@ -0,0 +1,155 @@
+package fuego
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream_Async(t *testing.T) {
+	s := NewStreamFromSlice([]int{1, 2, 3}, 2).Concurrent(3).MicroBatch(4)
+	assert.Nil(t, s.stream)
+
+	got := s.Async()
+	assert.NotNil(t, got.stream)
+	assert.Nil(t, got.pull)
+	assert.Equal(t, 2, cap(got.stream))
+	assert.Equal(t, 3, got.Concurrency())
+	assert.Equal(t, 4, got.MicroBatchSize())
+	assert.Equal(t, []int{1, 2, 3}, got.ToSlice())
+
+	c := make(chan int)
+	assert.Equal(t, c, NewStream(c).Async().stream)
+}
+
+func TestStream_Synchronous(t *testing.T) {
+	if DisableFusion {
+		t.Skip("stages of synchronous streams run in their own Go routine when fusion is disabled")
+	}
+
+	events := []string{}
+
+	s := NewStreamFromSlice([]int{1, 2, 3, 4, 5, 6}, 0).
+		Peek(func(i int) { events = append(events, "peek "+strconv.Itoa(i)) }).
+		Drop(1).
+		Filter(func(i int) bool { return i%2 == 0 }).
+		Take(2)
+
+	Map(s, strconv.Itoa).ForEach(func(s string) { events = append(events, "consume "+s) })
+
+	// stages are executed lazily, one element at a time, on the consumer's Go routine.
+	assert.Equal(t, []string{
+		"peek 1",
+		"peek 2",
+		"consume 2",
+		"peek 3",
+		"peek 4",
+		"consume 4",
+		"peek 5",
+		"peek 6", // Take(2) stops at the 3rd element
+	}, events)
+}
+
+func TestStream_Synchronous_ConcurrentConsumers(t *testing.T) {
+	const (
+		numEntries = 10_000
+		consumers  = 4
+	)
+
+	s := NewStreamFromSlice(intRange(numEntries), 0)
+	stages := s.Filter(True[int]()).DropWhile(func(i int) bool { return i < 10 }).Take(numEntries)
+	direct := s.Filter(True[int]())
+
+	seen := make([][]int, consumers)
+	wg := sync.WaitGroup{}
+	wg.Add(consumers)
+
+	for i := 0; i < consumers; i++ {
+		go func(i int) {
+			defer wg.Done()
+
+			// two of the consumers read from a different chain of stages of the same source.
+			consumed := stages
+			if i%2 == 1 {
+				consumed = direct
+			}
+
+			consumed.ForEach(func(val int) { seen[i] = append(seen[i], val) })
+		}(i)
+	}
+
+	wg.Wait()
+
+	// each element is delivered to one consumer only, as with a channel.
+	got := map[int]int{}
+	for _, vals := range seen {
+		for _, val := range vals {
+			got[val]++
+		}
+	}
+
+	for val, count := range got {
+		assert.Equal(t, 1, count, "element %d", val)
+	}
+
+	// the elements dropped by DropWhile may have been read by the other chain.
+	assert.GreaterOrEqual(t, len(got), numEntries-10)
+}
+
+func TestStream_Synchronous_TakeWhile(t *testing.T) {
+	if DisableFusion {
+		t.Skip("stages of synchronous streams run in their own Go routine when fusion is disabled")
+	}
+
+	pulled := 0
+
+	got := NewStreamFromSlice([]int{1, 2, 3, 4, 5}, 0).
+		Peek(func(int) { pulled++ }).
+		TakeWhile(func(i int) bool { return i < 3 })
+
+	assert.Equal(t, []int{1, 2}, got.ToSlice())
+	assert.Equal(t, []int{}, got.ToSlice())
+	assert.Equal(t, 3, pulled)
+}
+
+func TestStream_Synchronous_DropWhile(t *testing.T) {
+	got := NewStreamFromSlice([]int{1, 2, 3, 1, 2}, 0).
+		DropWhile(func(i int) bool { return i < 3 }).
+		ToSlice()
+
+	assert.Equal(t, []int{3, 1, 2}, got)
+}
+
+func TestStream_Synchronous_ConcurrentStage(t *testing.T) {
+	s := NewStreamFromSlice([]int{1, 2, 3, 4}, 0).
+		Filter(func(i int) bool { return i > 1 }).
+		Concurrent(2)
+
+	got := Map(s, func(i int) int { return i * 10 })
+	assert.NotNil(t, got.stream)
+	assert.Equal(t, []int{20, 30, 40}, got.ToSlice())
+}
+
+func BenchmarkFilterMapToSlice(b *testing.B) {
+	data := intRange(1000)
+	isEven := func(i int) bool { return i%2 == 0 }
+	double := func(i int) int { return i * 2 }
+
+	b.Run("synchronous", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Map(NewStreamFromSlice(data, 0).Filter(isEven), double).ToSlice()
+		}
+	})
+
+	b.Run("asynchronous", func(b *testing.B) {
+		// one Go routine and one channel per stage.
+		defer func(disabled bool) { DisableFusion = disabled }(DisableFusion)
+		DisableFusion = true
+
+		for i := 0; i < b.N; i++ {
+			_ = Map(NewStreamFromSlice(data, 0).Async().Filter(isEven), double).ToSlice()
+		}
+	})
+}
@@ -0,0 +1,355 @@
+package fuego
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DefaultCompression is the compression of a TDigest that offers a good compromise
+// between accuracy and memory usage.
+const DefaultCompression = 100
+
+// minCompression is the smallest compression accepted by NewTDigest.
+const minCompression = 20
+
+// tdigestVersion is the version of the binary encoding of a TDigest.
+const tdigestVersion = 1
+
+// tdigestHeaderLen is the length of the header of the binary encoding of a TDigest:
+// version, compression, min, max and number of centroids.
+const tdigestHeaderLen = 1 + 3*8 + 4
+
+// TDigest is a sketch that estimates the quantiles of a (possibly very large) set of
+// numbers in bounded memory, using Ted Dunning's merging t-digest.
+//
+// The numbers are clustered into centroids that are small at the tails of the
+// distribution and larger in the middle: the estimates of extreme quantiles (such as
+// p99 or p999) are therefore more accurate than those of the median. The number of
+// centroids, and hence the memory usage, is proportional to the compression and not
+// to the number of values. For a compression of 100, the error on the rank of the
+// estimated quantiles is typically under 1%. It decreases as the compression increases.
+//
+// Digests can be merged (see Merge) and serialised (see MarshalBinary), for instance to
+// combine the digests of several shards.
+//
+// A TDigest is not safe for concurrent use.
+type TDigest struct {
+	compression float64
+	centroids   []centroid // merged centroids, sorted by mean.
+	unmerged    []centroid // buffered centroids, yet to be merged.
+	count       float64
+	min         float64
+	max         float64
+}
+
+// centroid is a cluster of values of a TDigest.
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// NewTDigest creates an empty TDigest with the given compression (see DefaultCompression).
+// A higher compression improves the accuracy at the expense of memory.
+// Values below 20 are treated as 20.
+func NewTDigest(compression float64) *TDigest {
+	if !(compression >= minCompression) { // also catches NaN.
+		compression = minCompression
+	}
+
+	return &TDigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// Compression returns the compression of this TDigest.
+func (d *TDigest) Compression() float64 {
+	return d.compression
+}
+
+// Count returns the number of values added to this TDigest.
+func (d *TDigest) Count() int {
+	return int(d.count)
+}
+
+// Add adds a value to this TDigest. NaN values are ignored.
+func (d *TDigest) Add(x float64) {
+	if math.IsNaN(x) {
+		return
+	}
+
+	d.add(centroid{mean: x, weight: 1})
+	d.min = math.Min(d.min, x)
+	d.max = math.Max(d.max, x)
+}
+
+// Merge adds the values of the other TDigest to this TDigest.
+// The other TDigest is left unchanged.
+func (d *TDigest) Merge(other *TDigest) {
+	if other == nil || other.count == 0 {
+		return
+	}
+
+	for _, cs := range [][]centroid{other.centroids, other.unmerged} {
+		for _, c := range cs {
+			d.add(c)
+		}
+	}
+
+	d.min = math.Min(d.min, other.min)
+	d.max = math.Max(d.max, other.max)
+}
+
+// Quantile returns an estimate of the q-quantile of the values of this TDigest, where q
+// is between 0 and 1 (e.g. 0.99 for the 99th percentile). Values of q outside this
+// range are clamped. Quantile(0) and Quantile(1) are the exact minimum and maximum.
+//
+// NaN is returned when the TDigest is empty.
+func (d *TDigest) Quantile(q float64) float64 {
+	d.compress()
+
+	if d.count == 0 {
+		return math.NaN()
+	}
+
+	q = math.Min(math.Max(q, 0), 1)
+
+	// each centroid is deemed to sit at the middle of its weight: the quantile is
+	// interpolated between the two centroids that surround its rank, or between the
+	// extreme centroids and the exact min or max.
+	rank := q * d.count
+	cs := d.centroids
+
+	if rank <= cs[0].weight/2 {
+		return interpolate(d.min, 0, cs[0].mean, cs[0].weight/2, rank)
+	}
+
+	cumulated := 0.0
+
+	for idx := 0; idx < len(cs)-1; idx++ {
+		left := cumulated + cs[idx].weight/2
+		right := cumulated + cs[idx].weight + cs[idx+1].weight/2
+
+		if rank <= right {
+			return interpolate(cs[idx].mean, left, cs[idx+1].mean, right, rank)
+		}
+
+		cumulated += cs[idx].weight
+	}
+
+	last := cs[len(cs)-1]
+
+	return interpolate(last.mean, d.count-last.weight/2, d.max, d.count, rank)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (d *TDigest) MarshalBinary() ([]byte, error) {
+	d.compress()
+
+	// version, compression, min, max, centroid count, centroids (mean, weight).
+	buf := make([]byte, tdigestHeaderLen+16*len(d.centroids))
+	buf[0] = tdigestVersion
+	binary.BigEndian.PutUint64(buf[1:], math.Float64bits(d.compression))
+	binary.BigEndian.PutUint64(buf[9:], math.Float64bits(d.min))
+	binary.BigEndian.PutUint64(buf[17:], math.Float64bits(d.max))
+	binary.BigEndian.PutUint32(buf[25:], uint32(len(d.centroids)))
+
+	for idx, c := range d.centroids {
+		binary.BigEndian.PutUint64(buf[tdigestHeaderLen+16*idx:], math.Float64bits(c.mean))
+		binary.BigEndian.PutUint64(buf[tdigestHeaderLen+16*idx+8:], math.Float64bits(c.weight))
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It returns an error that matches ErrMalformedSketch when data is not a valid TDigest.
+func (d *TDigest) UnmarshalBinary(data []byte) error {
+	if len(data) < tdigestHeaderLen || data[0] != tdigestVersion {
+		return fmt.Errorf("%w: invalid t-digest header", ErrMalformedSketch)
+	}
+
+	n := int(binary.BigEndian.Uint32(data[tdigestHeaderLen-4:]))
+	if len(data) != tdigestHeaderLen+16*n {
+		return fmt.Errorf("%w: invalid t-digest length", ErrMalformedSketch)
+	}
+
+	float := func(offset int) float64 {
+		return math.Float64frombits(binary.BigEndian.Uint64(data[offset:]))
+	}
+
+	digest := TDigest{
+		compression: float(1),
+		min:         float(9),
+		max:         float(17),
+		centroids:   make([]centroid, n),
+	}
+
+	for idx := range digest.centroids {
+		c := centroid{
+			mean:   float(tdigestHeaderLen + 16*idx),
+			weight: float(tdigestHeaderLen + 16*idx + 8),
+		}
+
+		if !(c.weight > 0) || (idx > 0 && c.mean < digest.centroids[idx-1].mean) {
+			return fmt.Errorf("%w: invalid t-digest centroid", ErrMalformedSketch)
+		}
+
+		digest.centroids[idx] = c
+		digest.count += c.weight
+	}
+
+	if !(digest.compression >= minCompression) {
+		return fmt.Errorf("%w: invalid t-digest compression", ErrMalformedSketch)
+	}
+
+	*d = digest
+
+	return nil
+}
+
+// add buffers the centroid and merges the buffer when it is full.
+func (d *TDigest) add(c centroid) {
+	d.unmerged = append(d.unmerged, c)
+	d.count += c.weight
+
+	if len(d.unmerged) >= int(5*d.compression) {
+		d.compress()
+	}
+}
+
+// compress merges the buffered centroids into the centroids of this TDigest.
+//
+// Neighbouring centroids are merged for as long as the merged centroid spans at most one
+// unit of the scale function k(q) = compression / 2π * asin(2q - 1), whose slope is
+// steeper at the tails.
+func (d *TDigest) compress() {
+	if len(d.unmerged) == 0 {
+		return
+	}
+
+	all := append(d.centroids, d.unmerged...)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	merged := make([]centroid, 0, len(d.centroids)+1)
+	cur := all[0]
+	cumulated := 0.0
+	limit := d.quantileLimit(0)
+
+	for _, c := range all[1:] {
+		if (cumulated+cur.weight+c.weight)/d.count <= limit {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+
+			continue
+		}
+
+		merged = append(merged, cur)
+		cumulated += cur.weight
+		limit = d.quantileLimit(cumulated / d.count)
+		cur = c
+	}
+
+	d.centroids = append(merged, cur)
+	d.unmerged = nil
+}
+
+// quantileLimit returns the largest quantile that a centroid starting at quantile q may reach.
+func (d *TDigest) quantileLimit(q float64) float64 {
+	k := d.compression/(2*math.Pi)*math.Asin(2*q-1) + 1
+	if k >= d.compression/4 {
+		return 1
+	}
+
+	return (math.Sin(k*2*math.Pi/d.compression) + 1) / 2
+}
+
+// interpolate returns the value at x on the line that passes through (x1, y1) and (x2, y2).
+func interpolate(y1, x1, y2, x2, x float64) float64 {
+	if x2 <= x1 {
+		return y2
+	}
+
+	return y1 + (y2-y1)*(x-x1)/(x2-x1)
+}
+
+// QuantileSketch returns a concurrent collector that produces a TDigest of the input
+// elements, with the given compression (see NewTDigest). The digest estimates any
+// quantile of the elements in bounded memory. See Quantiles for exact quantiles.
+//
+// Example: the 95th and 99th percentiles of latencies:
+//
+//	digest := Collect(latencies, QuantileSketch[time.Duration](DefaultCompression))
+//	p95, p99 := digest.Quantile(0.95), digest.Quantile(0.99)
+func QuantileSketch[T Mathable](compression float64) Collector[T, *TDigest, *TDigest] {
+	supplier := func() *TDigest {
+		return NewTDigest(compression)
+	}
+
+	accumulator := func(d *TDigest, val T) *TDigest {
+		d.Add(toFloat64(val))
+		return d
+	}
+
+	combiner := func(d1, d2 *TDigest) *TDigest {
+		d1.Merge(d2)
+		return d1
+	}
+
+	return NewConcurrentCollector(supplier, accumulator, combiner, IdentityFinisher[*TDigest])
+}
+
+// Quantiles returns a concurrent collector that produces the exact q-quantiles of the
+// input elements, in the order of qs. The quantiles are linearly interpolated between
+// the closest ranks (as with the default method of R and NumPy). Values of q outside
+// the range [0, 1] are clamped.
+//
+// All the elements are retained and sorted: for large streams, prefer QuantileSketch.
+//
+// The quantiles are NaN when the stream is empty. Complex numbers are converted to
+// their real part.
+func Quantiles[T Mathable](qs ...float64) Collector[T, []float64, []float64] {
+	supplier := func() []float64 {
+		return []float64{}
+	}
+
+	accumulator := func(values []float64, val T) []float64 {
+		return append(values, toFloat64(val))
+	}
+
+	combiner := func(values1, values2 []float64) []float64 {
+		return append(values1, values2...)
+	}
+
+	finisher := func(values []float64) []float64 {
+		sort.Float64s(values)
+
+		result := make([]float64, len(qs))
+		for idx, q := range qs {
+			result[idx] = exactQuantile(values, q)
+		}
+
+		return result
+	}
+
+	return NewConcurrentCollector(supplier, accumulator, combiner, finisher)
+}
+
+// exactQuantile returns the q-quantile of the sorted values.
+func exactQuantile(values []float64, q float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+
+	rank := math.Min(math.Max(q, 0), 1) * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+
+	if lower == len(values)-1 {
+		return values[lower]
+	}
+
+	return interpolate(values[lower], float64(lower), values[lower+1], float64(lower+1), rank)
+}
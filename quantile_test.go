@@ -0,0 +1,274 @@
+package fuego
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rankError returns the difference between q and the proportion of the sorted values
+// that are below the estimate.
+func rankError(sorted []float64, q, estimate float64) float64 {
+	rank := sort.SearchFloat64s(sorted, estimate)
+	return math.Abs(float64(rank)/float64(len(sorted)) - q)
+}
+
+func TestTDigest_Quantile(t *testing.T) {
+	rnd := seededRand()
+
+	tt := map[string]func() float64{
+		"uniform":     rnd.Float64,
+		"normal":      rnd.NormFloat64,
+		"exponential": rnd.ExpFloat64,
+		"skewed": func() float64 {
+			return math.Pow(rnd.Float64(), 8) * 1e6
+		},
+	}
+
+	// the accuracy improves with the compression.
+	tolerances := map[float64]float64{
+		DefaultCompression: 0.01,
+		500:                0.002,
+	}
+
+	for name, gen := range tt {
+		values := make([]float64, 100_000)
+		for idx := range values {
+			values[idx] = gen()
+		}
+
+		for compression, tolerance := range tolerances {
+			compression, tolerance := compression, tolerance
+
+			t.Run(fmt.Sprintf("%s/compression=%v", name, compression), func(t *testing.T) {
+				d := NewTDigest(compression)
+				for _, v := range values {
+					d.Add(v)
+				}
+
+				sorted := append([]float64{}, values...)
+				sort.Float64s(sorted)
+
+				assert.Equal(t, len(values), d.Count())
+				assert.Equal(t, sorted[0], d.Quantile(0))
+				assert.Equal(t, sorted[len(sorted)-1], d.Quantile(1))
+
+				for _, q := range []float64{0.001, 0.01, 0.1, 0.5, 0.9, 0.95, 0.99, 0.999} {
+					assert.Less(t, rankError(sorted, q, d.Quantile(q)), tolerance, "q=%v", q)
+				}
+
+				// the memory usage is bounded by the compression.
+				assert.LessOrEqual(t, len(d.centroids), int(compression))
+			})
+		}
+	}
+}
+
+func TestTDigest_Quantile_SmallInputs(t *testing.T) {
+	d := NewTDigest(DefaultCompression)
+	assert.True(t, math.IsNaN(d.Quantile(0.5)))
+
+	d.Add(math.NaN())
+	assert.Equal(t, 0, d.Count())
+
+	d.Add(3)
+	assert.Equal(t, 3.0, d.Quantile(0))
+	assert.Equal(t, 3.0, d.Quantile(0.5))
+	assert.Equal(t, 3.0, d.Quantile(1))
+
+	d.Add(1)
+	assert.Equal(t, 1.0, d.Quantile(-1))
+	assert.Equal(t, 2.0, d.Quantile(0.5))
+	assert.Equal(t, 3.0, d.Quantile(2))
+}
+
+func TestNewTDigest(t *testing.T) {
+	assert.Equal(t, 20.0, NewTDigest(0).Compression())
+	assert.Equal(t, 20.0, NewTDigest(math.NaN()).Compression())
+	assert.Equal(t, 500.0, NewTDigest(500).Compression())
+}
+
+func TestTDigest_Merge(t *testing.T) {
+	rnd := seededRand()
+
+	values := make([]float64, 100_000)
+	shards := make([]*TDigest, 10)
+
+	for idx := range shards {
+		shards[idx] = NewTDigest(DefaultCompression)
+	}
+
+	for idx := range values {
+		values[idx] = rnd.NormFloat64()
+		shards[idx%7].Add(values[idx]) // 3 shards remain empty.
+	}
+
+	sort.Float64s(values)
+
+	got := NewTDigest(DefaultCompression)
+	for _, shard := range shards {
+		got.Merge(shard)
+	}
+
+	got.Merge(nil)
+
+	assert.Equal(t, len(values), got.Count())
+	assert.Equal(t, values[0], got.Quantile(0))
+	assert.Equal(t, values[len(values)-1], got.Quantile(1))
+
+	for _, q := range []float64{0.01, 0.5, 0.95, 0.99} {
+		assert.Less(t, rankError(values, q, got.Quantile(q)), 0.01, "q=%v", q)
+	}
+
+	assert.Equal(t, len(values)/7+1, shards[0].Count(), "merged shards must be left unchanged")
+}
+
+func TestTDigest_MarshalBinary(t *testing.T) {
+	rnd := seededRand()
+	d := NewTDigest(50)
+
+	for i := 0; i < 10_000; i++ {
+		d.Add(rnd.ExpFloat64())
+	}
+
+	data, err := d.MarshalBinary()
+	require.NoError(t, err)
+
+	got := &TDigest{}
+	require.NoError(t, got.UnmarshalBinary(data))
+
+	assert.Equal(t, d.Compression(), got.Compression())
+	assert.Equal(t, d.Count(), got.Count())
+
+	for _, q := range []float64{0, 0.1, 0.5, 0.99, 1} {
+		assert.Equal(t, d.Quantile(q), got.Quantile(q), "q=%v", q)
+	}
+
+	empty, err := NewTDigest(50).MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, got.UnmarshalBinary(empty))
+	assert.Equal(t, 0, got.Count())
+	assert.True(t, math.IsNaN(got.Quantile(0.5)))
+}
+
+func TestTDigest_UnmarshalBinary_Malformed(t *testing.T) {
+	valid, err := NewTDigest(50).MarshalBinary()
+	require.NoError(t, err)
+
+	d := NewTDigest(50)
+	d.Add(1)
+	d.Add(2)
+	twoCentroids, err := d.MarshalBinary()
+	require.NoError(t, err)
+
+	unordered := append([]byte{}, twoCentroids...)
+	copy(unordered[tdigestHeaderLen:], twoCentroids[tdigestHeaderLen+16:])
+	copy(unordered[tdigestHeaderLen+16:], twoCentroids[tdigestHeaderLen:tdigestHeaderLen+16])
+
+	tt := map[string][]byte{
+		"nil":               nil,
+		"unknown version":   append([]byte{2}, valid[1:]...),
+		"truncated":         twoCentroids[:len(twoCentroids)-1],
+		"extra bytes":       append(append([]byte{}, valid...), 0),
+		"unordered":         unordered,
+		"zero compression":  append(append([]byte{1}, make([]byte, 8)...), valid[9:]...),
+		"centroid weight 0": append(append([]byte{}, twoCentroids[:len(twoCentroids)-8]...), make([]byte, 8)...),
+	}
+
+	for name, data := range tt {
+		data := data
+
+		t.Run(name, func(t *testing.T) {
+			got := NewTDigest(50)
+			got.Add(7)
+
+			err := got.UnmarshalBinary(data)
+			assert.True(t, errors.Is(err, ErrMalformedSketch), err)
+			assert.Equal(t, 1, got.Count(), "the TDigest must be left unchanged")
+		})
+	}
+}
+
+func TestCollector_QuantileSketch(t *testing.T) {
+	values := make([]time.Duration, 10_000)
+	for idx := range values {
+		values[idx] = time.Duration(idx+1) * time.Millisecond
+	}
+
+	tt := map[string]Stream[time.Duration]{
+		"sequential": NewStreamFromSlice(values, 0),
+		"concurrent": NewStreamFromSlice(values, 10).Concurrent(4),
+	}
+
+	for name, stream := range tt {
+		stream := stream
+
+		t.Run(name, func(t *testing.T) {
+			c := QuantileSketch[time.Duration](DefaultCompression)
+			assert.True(t, c.IsConcurrent())
+
+			got := Collect(stream, c)
+			assert.Equal(t, len(values), got.Count())
+			assert.Equal(t, float64(time.Millisecond), got.Quantile(0))
+			assert.InEpsilon(t, float64(9_900*time.Millisecond), got.Quantile(0.99), 0.001)
+			assert.InEpsilon(t, float64(5_000*time.Millisecond), got.Quantile(0.5), 0.01)
+		})
+	}
+}
+
+func TestCollector_Quantiles(t *testing.T) {
+	tt := map[string]struct {
+		stream Stream[int]
+		qs     []float64
+		want   []float64
+	}{
+		"Should return NaN for an empty Stream": {
+			stream: NewStreamFromSlice([]int{}, 0),
+			qs:     []float64{0.5},
+			want:   []float64{math.NaN()},
+		},
+		"Should return no quantile when none is requested": {
+			stream: NewStreamFromSlice([]int{1, 2}, 0),
+			want:   []float64{},
+		},
+		"Should return the only element": {
+			stream: NewStreamFromSlice([]int{7}, 0),
+			qs:     []float64{0, 0.5, 1},
+			want:   []float64{7, 7, 7},
+		},
+		"Should interpolate the quantiles": {
+			stream: NewStreamFromSlice([]int{4, 1, 3, 2, 5}, 0),
+			qs:     []float64{0.5, 0, 1, 0.1, 0.75, -1, 2},
+			want:   []float64{3, 1, 5, 1.4, 4, 1, 5},
+		},
+		"Should return the quantiles of a concurrent Stream": {
+			stream: NewStreamFromSlice(intRange(101), 10).Concurrent(4),
+			qs:     []float64{0.5, 0.95, 0.99},
+			want:   []float64{50, 95, 99},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := Collect(tc.stream, Quantiles[int](tc.qs...))
+			require.Len(t, got, len(tc.want))
+
+			for idx := range tc.want {
+				if math.IsNaN(tc.want[idx]) {
+					assert.True(t, math.IsNaN(got[idx]))
+					continue
+				}
+
+				assert.InDelta(t, tc.want[idx], got[idx], 1e-9)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package fuego
+
+import "sync"
+
+// releaser signals to the source of a Stream that its remaining elements are no
+// longer required. It is shared by all the Streams derived from the source.
+//
+// Only sources owned by fuego (such as NewStreamFromSlice) have a releaser: a
+// channel supplied by the user is never drained nor interrupted by fuego.
+//
+// Releasing does not propagate across methods that split a Stream (e.g. Tee)
+// since the other output Streams may still require the elements.
+type releaser struct {
+	once sync.Once
+	done chan struct{}
+}
+
+// newReleaser creates a new releaser.
+func newReleaser() *releaser {
+	return &releaser{
+		done: make(chan struct{}),
+	}
+}
+
+// release signals to the source that its remaining elements are no longer required.
+// It is safe to call release several times and on a nil releaser.
+func (r *releaser) release() {
+	if r == nil {
+		return
+	}
+
+	r.once.Do(func() { close(r.done) })
+}
+
+// released returns a channel that is closed once the source is released.
+// A nil releaser returns a nil channel, which is never ready.
+func (r *releaser) released() <-chan struct{} {
+	if r == nil {
+		return nil
+	}
+
+	return r.done
+}
+
+// release signals to the source of this stream that its remaining elements are no
+// longer required, and discards the elements still in transit so that the upstream
+// stages can complete.
+//
+// It has no effect on streams whose source is not owned by fuego.
+func (s Stream[T]) release() {
+	if s.releaser == nil || s.isNil() {
+		return
+	}
+
+	s.releaser.release()
+
+	if s.stream == nil {
+		// synchronous streams have no element in transit.
+		return
+	}
+
+	go func() {
+		for range s.stream { // nolint: revive
+		}
+	}()
+}
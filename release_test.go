@@ -0,0 +1,72 @@
+package fuego
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaser_NilIsSafe(t *testing.T) {
+	var r *releaser
+
+	assert.NotPanics(t, r.release)
+	assert.Nil(t, r.released())
+}
+
+func TestReleaser_ReleaseIsIdempotent(t *testing.T) {
+	r := newReleaser()
+
+	r.release()
+	r.release()
+
+	select {
+	case <-r.released():
+	default:
+		t.Error("releaser was not released")
+	}
+}
+
+func TestStream_release(t *testing.T) {
+	s := NewStreamFromSlice(intRange(1000), 0)
+	derived := Map(s.Filter(True[int]()).Concurrent(2), Identity[int])
+
+	assert.Equal(t, 0, derived.Head())
+
+	derived.release()
+
+	select {
+	case <-s.releaser.released():
+	default:
+		t.Error("the source was not released")
+	}
+
+	// the source stops producing and the upstream stages complete
+	select {
+	case <-waitClosed(derived.stream):
+	case <-time.After(time.Second):
+		t.Error("the upstream stages did not complete")
+	}
+}
+
+func TestStream_release_NoReleaser(t *testing.T) {
+	c := make(chan int, 1)
+	c <- 1
+
+	NewStream(c).release()
+
+	assert.Equal(t, 1, <-c)
+}
+
+// waitClosed returns a channel that is closed once channel c was closed and drained.
+func waitClosed[T any](c chan T) chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for range c { // nolint: revive
+		}
+	}()
+
+	return done
+}
@@ -0,0 +1,144 @@
+package fuego
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryAttempt describes one attempt at applying an ErrorFunction to an element.
+type RetryAttempt[T any] struct {
+	// Element is the element of the stream the function was applied to.
+	Element T
+	// Attempt is the number of the attempt, starting at 1.
+	Attempt int
+	// Err is the error returned by the function, or nil when the attempt succeeded.
+	Err error
+}
+
+// RetryPolicy specifies how to retry an ErrorFunction that failed.
+//
+// The zero value performs a single attempt with no retry.
+type RetryPolicy[T any] struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Values below 1 are treated as 1.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts. No cap is applied when 0.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor applied to the delay after each retry (exponential backoff).
+	// Values below 1 are treated as 1 (constant backoff).
+	Multiplier float64
+
+	// Jitter is the proportion (between 0 and 1) of the delay that is randomised.
+	// For instance, with a Jitter of 0.2, the delay is randomly reduced by up to 20%.
+	Jitter float64
+
+	// Retryable decides whether an error is worth retrying. All errors are retried when nil.
+	Retryable Predicate[error]
+
+	// Fallback produces the result for an element when all attempts failed or when the
+	// error is not retryable. It receives the element and the last error.
+	// When nil, the stream panics with a RetriesExhaustedError. The panic is raised on the
+	// Go routine of the terminal operation: TryCollect returns it as an error.
+	Fallback BiFunction[T, error, Any]
+
+	// OnAttempt is called after each attempt. It is useful for observability purposes.
+	// It may be called concurrently when the stream is concurrent.
+	OnAttempt Consumer[RetryAttempt[T]]
+}
+
+// MapWithRetry returns a Stream consisting of the result of applying the given
+// ErrorFunction to the elements of this stream, retrying failed calls as per the
+// supplied RetryPolicy.
+//
+// This is a concurrent method akin to Map: the order of the elements is preserved,
+// including when some of them are retried.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func (s Stream[T]) MapWithRetry(policy RetryPolicy[T], mapper ErrorFunction[T, Any]) Stream[Any] {
+	h, l := s.hooks("MapWithRetry"), s.log()
+
+	if h != nil || l != nil {
+		errorFn := mapper
+		mapper = func(val T) (Any, error) {
+			res, err := errorFn(val)
+			if err != nil {
+				h.error(err)
+
+				if l != nil {
+					l.Debug("mapper failed", "value", val, "error", err)
+				}
+			}
+
+			return res, err
+		}
+	}
+
+	return planStage(s, derivedStream(s, orderlyConcurrentDo(s, h, policy.retry(mapper))), "MapWithRetry")
+}
+
+// retry decorates an ErrorFunction into a Function that applies the RetryPolicy.
+func (p RetryPolicy[T]) retry(fn ErrorFunction[T, Any]) Function[T, Any] {
+	return func(val T) Any {
+		for attempt := 1; ; attempt++ {
+			result, err := fn(val)
+
+			if p.OnAttempt != nil {
+				p.OnAttempt(RetryAttempt[T]{Element: val, Attempt: attempt, Err: err})
+			}
+
+			if err == nil {
+				return result
+			}
+
+			if attempt >= p.MaxAttempts || (p.Retryable != nil && !p.Retryable(err)) {
+				if p.Fallback == nil {
+					panic(RetriesExhaustedError{Err: err})
+				}
+
+				return p.Fallback(val, err)
+			}
+
+			time.Sleep(p.backoff(attempt))
+		}
+	}
+}
+
+// backoff returns the delay to wait for after the given attempt has failed.
+func (p RetryPolicy[T]) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	maxBackoff := time.Duration(math.MaxInt64) // the delay would otherwise overflow.
+	if p.MaxBackoff > 0 {
+		maxBackoff = p.MaxBackoff
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+
+	if delay >= float64(maxBackoff) {
+		delay = float64(maxBackoff)
+	}
+
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		delay -= delay * jitter * rand.Float64() // nolint: gosec
+	}
+
+	if delay >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(delay)
+}
@@ -0,0 +1,231 @@
+package fuego
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errTransient = errors.New("transient error")
+
+var errPermanent = errors.New("permanent error")
+
+// failingTimesTwo returns a function that fails the given number of times
+// per element before succeeding with the double of the element.
+func failingTimesTwo(failures map[int]int, err error) ErrorFunction[int, Any] {
+	mu := sync.Mutex{}
+	calls := map[int]int{}
+
+	return func(i int) (Any, error) {
+		mu.Lock()
+		calls[i]++
+		call := calls[i]
+		mu.Unlock()
+
+		if call <= failures[i] {
+			return nil, err
+		}
+
+		return 2 * i, nil
+	}
+}
+
+func TestStream_MapWithRetry(t *testing.T) {
+	negate := func(i int, _ error) Any { return -i }
+
+	tt := map[string]struct {
+		policy   RetryPolicy[int]
+		failures map[int]int
+		err      error
+		want     []int
+	}{
+		"Should map without retry when no failure": {
+			policy:   RetryPolicy[int]{MaxAttempts: 3},
+			failures: map[int]int{},
+			err:      errTransient,
+			want:     []int{2, 4, 6, 8},
+		},
+		"Should retry transient failures": {
+			policy:   RetryPolicy[int]{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2},
+			failures: map[int]int{1: 2, 3: 1},
+			err:      errTransient,
+			want:     []int{2, 4, 6, 8},
+		},
+		"Should fall back when attempts are exhausted": {
+			policy:   RetryPolicy[int]{MaxAttempts: 2, Fallback: negate},
+			failures: map[int]int{2: 2, 4: 1},
+			err:      errTransient,
+			want:     []int{2, -2, 6, 8},
+		},
+		"Should fall back without retry when the error is not retryable": {
+			policy: RetryPolicy[int]{
+				MaxAttempts: 5,
+				Retryable:   func(err error) bool { return errors.Is(err, errTransient) },
+				Fallback:    negate,
+			},
+			failures: map[int]int{3: 1},
+			err:      errPermanent,
+			want:     []int{2, 4, -3, 8},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := C(NewStreamFromSlice([]int{1, 2, 3, 4}, 0).
+				MapWithRetry(tc.policy, failingTimesTwo(tc.failures, tc.err)), Int).
+				ToSlice()
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStream_MapWithRetry_PanicsWhenExhaustedWithoutFallback(t *testing.T) {
+	policy := RetryPolicy[int]{MaxAttempts: 2}
+	mapper := failingTimesTwo(map[int]int{1: 2}, errTransient)
+
+	assert.PanicsWithValue(t, RetriesExhaustedError{Err: errTransient}, func() { _ = policy.retry(mapper)(1) })
+}
+
+func TestStream_MapWithRetry_TryCollectReturnsRetriesExhausted(t *testing.T) {
+	for _, concurrency := range []int{0, 1, 4} {
+		concurrency := concurrency
+
+		t.Run(strconv.Itoa(concurrency), func(t *testing.T) {
+			s := NewStreamFromSlice(intRange(100), 0).Concurrent(concurrency)
+			mapper := failingTimesTwo(map[int]int{50: 5}, errTransient)
+
+			got, err := TryCollect(s.MapWithRetry(RetryPolicy[int]{MaxAttempts: 2}, mapper), ToSlice[Any]())
+
+			assert.Nil(t, got)
+			assert.Equal(t, RetriesExhaustedError{Err: errTransient}, err)
+			assert.True(t, errors.Is(err, ErrRetriesExhausted))
+		})
+	}
+}
+
+func TestStream_MapWithRetry_ResumesPanicOnConsumer(t *testing.T) {
+	s := NewStream(chanOf(intRange(100))).Concurrent(4)
+	mapper := failingTimesTwo(map[int]int{50: 5}, errTransient)
+
+	assert.PanicsWithValue(t, RetriesExhaustedError{Err: errTransient}, func() {
+		s.MapWithRetry(RetryPolicy[int]{MaxAttempts: 2}, mapper).ForEach(func(Any) {})
+	})
+}
+
+func TestStream_MapWithRetry_Concurrent_PreservesOrder(t *testing.T) {
+	const numEntries = 100
+
+	data := make([]int, numEntries)
+	want := make([]int, numEntries)
+	failures := map[int]int{}
+
+	for i := range data {
+		data[i] = i
+		want[i] = 2 * i
+		failures[i] = i % 3 // some elements are retried more than others
+	}
+
+	mu := sync.Mutex{}
+	attempts := map[int]int{}
+
+	policy := RetryPolicy[int]{
+		MaxAttempts:    3,
+		InitialBackoff: 5 * time.Millisecond,
+		Multiplier:     2,
+		Jitter:         0.5,
+		OnAttempt: func(a RetryAttempt[int]) {
+			mu.Lock()
+			defer mu.Unlock()
+			attempts[a.Element] = a.Attempt
+		},
+	}
+
+	got := C(NewStreamFromSlice(data, 10).
+		Concurrent(10).
+		MapWithRetry(policy, failingTimesTwo(failures, errTransient)), Int).
+		ToSlice()
+
+	assert.Equal(t, want, got)
+
+	for i := range data {
+		assert.Equal(t, failures[i]+1, attempts[i])
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	tt := map[string]struct {
+		policy  RetryPolicy[int]
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		"zero value": {
+			policy:  RetryPolicy[int]{},
+			attempt: 3,
+			min:     0,
+			max:     0,
+		},
+		"constant backoff": {
+			policy:  RetryPolicy[int]{InitialBackoff: time.Second},
+			attempt: 3,
+			min:     time.Second,
+			max:     time.Second,
+		},
+		"exponential backoff": {
+			policy:  RetryPolicy[int]{InitialBackoff: time.Second, Multiplier: 2},
+			attempt: 4,
+			min:     8 * time.Second,
+			max:     8 * time.Second,
+		},
+		"capped exponential backoff": {
+			policy:  RetryPolicy[int]{InitialBackoff: time.Second, Multiplier: 2, MaxBackoff: 5 * time.Second},
+			attempt: 4,
+			min:     5 * time.Second,
+			max:     5 * time.Second,
+		},
+		"exponential backoff with jitter": {
+			policy:  RetryPolicy[int]{InitialBackoff: time.Second, Multiplier: 2, Jitter: 0.25},
+			attempt: 3,
+			min:     3 * time.Second,
+			max:     4 * time.Second,
+		},
+		"uncapped exponential backoff after many attempts": {
+			policy:  RetryPolicy[int]{InitialBackoff: 100 * time.Millisecond, Multiplier: 2},
+			attempt: 40,
+			min:     time.Duration(math.MaxInt64),
+			max:     time.Duration(math.MaxInt64),
+		},
+		"uncapped exponential backoff with jitter after many attempts": {
+			policy:  RetryPolicy[int]{InitialBackoff: 100 * time.Millisecond, Multiplier: 2, Jitter: 0.25},
+			attempt: 2000,
+			min:     time.Duration(math.MaxInt64 / 4 * 3),
+			max:     time.Duration(math.MaxInt64),
+		},
+		"zero value with a multiplier after many attempts": {
+			policy:  RetryPolicy[int]{Multiplier: 2},
+			attempt: 2000,
+			min:     0,
+			max:     0,
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				got := tc.policy.backoff(tc.attempt)
+				assert.GreaterOrEqual(t, got, tc.min)
+				assert.LessOrEqual(t, got, tc.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package fuego
+
+import (
+	"math/rand"
+	"time"
+)
+
+// NOTICE:
+// The functions in this file accept a *rand.Rand so that results can be made
+// reproducible (e.g. in tests) by supplying a seeded source.
+// A *rand.Rand is not safe for concurrent use: do not share it between Streams
+// that are processed concurrently.
+// When nil, a new *rand.Rand seeded with the current time is used.
+
+// newRandIfNil returns rnd, or a new time-seeded *rand.Rand when rnd is nil.
+func newRandIfNil(rnd *rand.Rand) *rand.Rand {
+	if rnd != nil {
+		return rnd
+	}
+
+	return rand.New(rand.NewSource(time.Now().UnixNano())) // nolint: gosec
+}
+
+// Sample returns a stream consisting of the elements of this stream, each of which is
+// retained independently with probability p (i.e. Bernoulli sampling).
+//
+// p <= 0 retains no element, p >= 1 retains all elements.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func (s Stream[T]) Sample(p float64, rnd *rand.Rand) Stream[T] {
+	rnd = newRandIfNil(rnd)
+
+	s = s.Async()
+	outstream := make(chan T, cap(s.stream))
+
+	go func() {
+		defer close(outstream)
+
+		if s.stream == nil {
+			return
+		}
+
+		for val := range s.stream {
+			if rnd.Float64() < p {
+				outstream <- val
+			}
+		}
+	}()
+
+	return planStage(s, derivedStream(s, outstream), "Sample")
+}
+
+// EveryNth returns a stream consisting of every nth element of this stream,
+// i.e. the elements at positions n, 2n, 3n, etc.
+//
+// n < 2 retains all elements.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func (s Stream[T]) EveryNth(n uint64) Stream[T] {
+	s = s.Async()
+	outstream := make(chan T, cap(s.stream))
+
+	go func() {
+		defer close(outstream)
+
+		if s.stream == nil {
+			return
+		}
+
+		count := uint64(0)
+
+		for val := range s.stream {
+			if count++; count >= n {
+				count = 0
+				outstream <- val
+			}
+		}
+	}()
+
+	return planStage(s, derivedStream(s, outstream), "EveryNth")
+}
+
+// Shuffle returns a stream consisting of the elements of this stream in a random order.
+//
+// Shuffling is performed over a sliding window of windowSize elements (at least one) held
+// in memory: an element can only be moved forward or backward by about windowSize positions.
+// A window at least as large as the stream produces a uniform shuffle.
+//
+// Note that no element is emitted until windowSize elements have been read or the in-stream
+// is closed.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func (s Stream[T]) Shuffle(windowSize int, rnd *rand.Rand) Stream[T] {
+	rnd = newRandIfNil(rnd)
+
+	if windowSize < 1 {
+		windowSize = 1
+	}
+
+	s = s.Async()
+	outstream := make(chan T, cap(s.stream))
+
+	go func() {
+		defer close(outstream)
+
+		if s.stream == nil {
+			return
+		}
+
+		window := make([]T, 0, windowSize)
+
+		for val := range s.stream {
+			if len(window) < windowSize {
+				window = append(window, val)
+				continue
+			}
+
+			idx := rnd.Intn(windowSize)
+			outstream <- window[idx]
+			window[idx] = val
+		}
+
+		rnd.Shuffle(len(window), func(i, j int) { window[i], window[j] = window[j], window[i] })
+
+		for _, val := range window {
+			outstream <- val
+		}
+	}()
+
+	return planStage(s, derivedStream(s, outstream), "Shuffle")
+}
+
+// Reservoir is the accumulator of the ReservoirSample Collector.
+// Its state is internal to the Collector.
+type Reservoir[T any] struct {
+	seen   int
+	sample []T
+}
+
+// ReservoirSample returns a collector that draws a uniform random sample of (at most) k
+// elements from the input elements, using constant memory (reservoir sampling, Algorithm R).
+//
+// The order of the elements in the resulting slice is not significant.
+//
+// Since the *rand.Rand is shared by all the reductions performed with this Collector,
+// such reductions must not run concurrently.
+func ReservoirSample[T any](k int, rnd *rand.Rand) Collector[T, *Reservoir[T], []T] {
+	rnd = newRandIfNil(rnd)
+
+	if k < 0 {
+		k = 0
+	}
+
+	supplier := func() *Reservoir[T] {
+		return &Reservoir[T]{
+			sample: make([]T, 0, k),
+		}
+	}
+
+	accumulator := func(r *Reservoir[T], element T) *Reservoir[T] {
+		r.seen++
+
+		if len(r.sample) < k {
+			r.sample = append(r.sample, element)
+			return r
+		}
+
+		if idx := rnd.Intn(r.seen); idx < k {
+			r.sample[idx] = element
+		}
+
+		return r
+	}
+
+	finisher := func(r *Reservoir[T]) []T {
+		return r.sample
+	}
+
+	return NewCollector(supplier, accumulator, finisher)
+}
@@ -0,0 +1,213 @@
+package fuego
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func seededRand() *rand.Rand {
+	return rand.New(rand.NewSource(42)) // nolint: gosec
+}
+
+func intRange(n int) []int {
+	ints := make([]int, n)
+	for i := range ints {
+		ints[i] = i
+	}
+
+	return ints
+}
+
+func TestStream_Sample(t *testing.T) {
+	tt := map[string]struct {
+		stream Stream[int]
+		p      float64
+		want   []int
+	}{
+		"Should return an empty stream when nil in-stream": {
+			stream: Stream[int]{stream: nil},
+			p:      0.5,
+			want:   []int{},
+		},
+		"Should retain no element when p is 0": {
+			stream: NewStreamFromSlice(intRange(10), 0),
+			p:      0,
+			want:   []int{},
+		},
+		"Should retain all elements when p is 1": {
+			stream: NewStreamFromSlice(intRange(10), 0),
+			p:      1,
+			want:   intRange(10),
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := tc.stream.Sample(tc.p, seededRand()).ToSlice()
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStream_Sample_IsReproducibleAndRepresentative(t *testing.T) {
+	const numEntries = 10000
+
+	got1 := NewStreamFromSlice(intRange(numEntries), 100).Sample(0.1, seededRand()).ToSlice()
+	got2 := NewStreamFromSlice(intRange(numEntries), 100).Sample(0.1, seededRand()).ToSlice()
+
+	assert.Equal(t, got1, got2)
+	assert.InDelta(t, numEntries/10, len(got1), numEntries/100)
+	assert.True(t, sort.IntsAreSorted(got1))
+}
+
+func TestStream_EveryNth(t *testing.T) {
+	tt := map[string]struct {
+		stream Stream[int]
+		n      uint64
+		want   []int
+	}{
+		"Should return an empty stream when nil in-stream": {
+			stream: Stream[int]{stream: nil},
+			n:      2,
+			want:   []int{},
+		},
+		"Should retain all elements when n is 0": {
+			stream: NewStreamFromSlice(intRange(5), 0),
+			n:      0,
+			want:   []int{0, 1, 2, 3, 4},
+		},
+		"Should retain all elements when n is 1": {
+			stream: NewStreamFromSlice(intRange(5), 0),
+			n:      1,
+			want:   []int{0, 1, 2, 3, 4},
+		},
+		"Should retain every third element": {
+			stream: NewStreamFromSlice(intRange(10), 0),
+			n:      3,
+			want:   []int{2, 5, 8},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := tc.stream.EveryNth(tc.n).ToSlice()
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStream_Shuffle(t *testing.T) {
+	tt := map[string]struct {
+		stream     Stream[int]
+		windowSize int
+		want       []int
+	}{
+		"Should return an empty stream when nil in-stream": {
+			stream:     Stream[int]{stream: nil},
+			windowSize: 5,
+			want:       []int{},
+		},
+		"Should not shuffle when window size is 1": {
+			stream:     NewStreamFromSlice(intRange(10), 0),
+			windowSize: 1,
+			want:       intRange(10),
+		},
+		"Should not shuffle when window size is 0": {
+			stream:     NewStreamFromSlice(intRange(10), 0),
+			windowSize: 0,
+			want:       intRange(10),
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := tc.stream.Shuffle(tc.windowSize, seededRand()).ToSlice()
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStream_Shuffle_IsReproduciblePermutation(t *testing.T) {
+	const numEntries = 100
+
+	for _, windowSize := range []int{2, 10, numEntries, 2 * numEntries} {
+		got1 := NewStreamFromSlice(intRange(numEntries), 0).Shuffle(windowSize, seededRand()).ToSlice()
+		got2 := NewStreamFromSlice(intRange(numEntries), 0).Shuffle(windowSize, seededRand()).ToSlice()
+
+		assert.Equal(t, got1, got2)
+		assert.NotEqual(t, intRange(numEntries), got1)
+
+		sort.Ints(got1)
+		assert.Equal(t, intRange(numEntries), got1)
+	}
+}
+
+func TestCollector_ReservoirSample(t *testing.T) {
+	tt := map[string]struct {
+		input    []int
+		k        int
+		wantSize int
+	}{
+		"Should return an empty sample when empty in-stream": {
+			input:    []int{},
+			k:        5,
+			wantSize: 0,
+		},
+		"Should return an empty sample when k is 0": {
+			input:    intRange(10),
+			k:        0,
+			wantSize: 0,
+		},
+		"Should return all elements when fewer than k": {
+			input:    intRange(3),
+			k:        5,
+			wantSize: 3,
+		},
+		"Should return k elements": {
+			input:    intRange(1000),
+			k:        10,
+			wantSize: 10,
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := Collect(NewStreamFromSlice(tc.input, 0), ReservoirSample[int](tc.k, seededRand()))
+			assert.Len(t, got, tc.wantSize)
+			assert.Subset(t, tc.input, got)
+		})
+	}
+}
+
+func TestCollector_ReservoirSample_IsReproducibleAndUniform(t *testing.T) {
+	const numEntries = 10
+	const numRuns = 20000
+
+	got1 := Collect(NewStreamFromSlice(intRange(1000), 0), ReservoirSample[int](10, seededRand()))
+	got2 := Collect(NewStreamFromSlice(intRange(1000), 0), ReservoirSample[int](10, seededRand()))
+	assert.Equal(t, got1, got2)
+
+	rnd := seededRand()
+	collector := ReservoirSample[int](1, rnd)
+	counts := make([]int, numEntries)
+
+	for i := 0; i < numRuns; i++ {
+		got := Collect(NewStreamFromSlice(intRange(numEntries), 0), collector)
+		counts[got[0]]++
+	}
+
+	for _, count := range counts {
+		assert.InDelta(t, numRuns/numEntries, count, numRuns/numEntries/10)
+	}
+}
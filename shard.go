@@ -0,0 +1,143 @@
+package fuego
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// KeyHashFunction is a Function that hashes the key of an element.
+// It is used to distribute elements across shards.
+type KeyHashFunction func(key Any) uint64
+
+// FNVKeyHash is the default KeyHashFunction.
+// It computes the 64-bit FNV-1a hash of the key's type and textual representation.
+//
+// Keys whose textual representation (as formatted by "%v") is not stable, such as
+// pointers to mutable data, should be given a bespoke KeyHashFunction.
+func FNVKeyHash(key Any) uint64 {
+	h := fnv.New64a()
+	// the key is prefixed with its type in case keys of 2 or more types share the same representation.
+	_, _ = fmt.Fprintf(h, "%T%v", key, key)
+
+	return h.Sum64()
+}
+
+// MapByKey returns a Stream consisting of the result of
+// applying the given function to the elements of this stream.
+//
+// Each element is assigned to one of n shards (at least one) by hashing its key, as
+// obtained with keyFn. Each shard is processed sequentially by its own worker and the
+// shards are processed concurrently. Hence, elements that share the same key are
+// mapped and emitted in their original order while elements of different keys are
+// processed in parallel and their relative order is NOT preserved.
+//
+// Keys are hashed with FNVKeyHash. See MapByKeyWithHash to supply a different hash
+// function, e.g. to reduce skew across shards.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func (s Stream[T]) MapByKey(keyFn Function[T, Any], n int, mapper Function[T, Any]) Stream[Any] {
+	return s.MapByKeyWithHash(keyFn, FNVKeyHash, n, mapper)
+}
+
+// MapByKeyWithHash is akin to MapByKey but hashes keys with the given hashFn.
+//
+// Shards are selected from the hash with a consistent hashing algorithm (Jump
+// Consistent Hash) such that changing the number of shards only moves a minimal
+// proportion of the keys to a different shard.
+//
+// Dispatching elements to shards is sequential: should a shard fall behind, its
+// buffer (of the same capacity as this stream's) fills up and the other shards stop
+// receiving elements until it catches up.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func (s Stream[T]) MapByKeyWithHash(keyFn Function[T, Any], hashFn KeyHashFunction, n int, mapper Function[T, Any]) Stream[Any] {
+	if n < 1 {
+		n = 1
+	}
+
+	h := s.hooks("MapByKey")
+	mapper = instrumentInput(h, instrumentFunction(h, mapper))
+
+	s = s.Async()
+	outstream := make(chan Any, cap(s.stream))
+
+	go func() {
+		defer close(outstream)
+		defer h.close()
+
+		h.start()
+
+		if s.stream == nil {
+			return
+		}
+
+		shards := make([]chan T, n)
+
+		wg := sync.WaitGroup{}
+		wg.Add(n)
+
+		for idx := range shards {
+			shards[idx] = make(chan T, cap(s.stream))
+
+			go func(shard chan T) {
+				defer wg.Done()
+
+				s.failure.run(func() {
+					for {
+						select {
+						case val, ok := <-shard:
+							if !ok {
+								return
+							}
+
+							send(h, outstream, mapper(val))
+						case <-s.failure.failed():
+							return
+						}
+					}
+				})
+			}(shards[idx])
+		}
+
+		s.failure.run(func() {
+			for val, ok := s.receiveUntilFailed(); ok; val, ok = s.receiveUntilFailed() {
+				select {
+				case shards[jumpHash(hashFn(keyFn(val)), n)] <- val:
+				case <-s.failure.failed():
+					return
+				}
+			}
+		})
+
+		for _, shard := range shards {
+			close(shard)
+		}
+
+		wg.Wait()
+
+		if s.failure.hasFailed() {
+			s.release()
+		}
+	}()
+
+	return planStage(s, derivedStream(s, outstream), "MapByKey")
+}
+
+// jumpHash returns the bucket (in the range [0, buckets)) that corresponds to key.
+//
+// This is the Jump Consistent Hash algorithm by John Lamping and Eric Veach:
+// https://arxiv.org/abs/1406.2294
+func jumpHash(key uint64, buckets int) int {
+	var b, j int64 = -1, 0
+
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+
+	return int(b)
+}
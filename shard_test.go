@@ -0,0 +1,159 @@
+package fuego
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type accountEvent struct {
+	account string
+	seq     int
+}
+
+func (e accountEvent) Account() Any {
+	return e.account
+}
+
+func getAccountEventsSample(numAccounts, numEventsPerAccount int) []accountEvent {
+	events := []accountEvent{}
+
+	for seq := 0; seq < numEventsPerAccount; seq++ {
+		for acc := 0; acc < numAccounts; acc++ {
+			events = append(events, accountEvent{account: string(rune('A' + acc)), seq: seq})
+		}
+	}
+
+	return events
+}
+
+func TestStream_MapByKey(t *testing.T) {
+	tt := map[string]struct {
+		stream Stream[accountEvent]
+		n      int
+		want   map[string][]int
+	}{
+		"Should return an empty stream when nil in-stream": {
+			stream: Stream[accountEvent]{stream: nil},
+			n:      4,
+			want:   map[string][]int{},
+		},
+		"Should preserve per-key order with a single shard": {
+			stream: NewStreamFromSlice(getAccountEventsSample(3, 5), 0),
+			n:      0,
+			want: map[string][]int{
+				"A": {0, 1, 2, 3, 4},
+				"B": {0, 1, 2, 3, 4},
+				"C": {0, 1, 2, 3, 4},
+			},
+		},
+		"Should preserve per-key order with several shards": {
+			stream: NewStreamFromSlice(getAccountEventsSample(5, 6), 0),
+			n:      3,
+			want: map[string][]int{
+				"A": {0, 1, 2, 3, 4, 5},
+				"B": {0, 1, 2, 3, 4, 5},
+				"C": {0, 1, 2, 3, 4, 5},
+				"D": {0, 1, 2, 3, 4, 5},
+				"E": {0, 1, 2, 3, 4, 5},
+			},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			jitteryIdentity := func(e accountEvent) Any {
+				time.Sleep(time.Duration(e.seq%3) * time.Millisecond)
+				return e
+			}
+
+			got := map[string][]int{}
+
+			tc.stream.
+				MapByKey(accountEvent.Account, tc.n, jitteryIdentity).
+				ForEach(func(e Any) {
+					ev := e.(accountEvent)
+					got[ev.account] = append(got[ev.account], ev.seq)
+				})
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStream_MapByKey_Concurrent(t *testing.T) {
+	const numAccounts = 30
+	const numEventsPerAccount = 10
+
+	slowIdentity := func(e accountEvent) Any {
+		time.Sleep(50 * time.Millisecond)
+		return e
+	}
+
+	start := time.Now()
+
+	// a hash function that places each account in its own shard
+	accountIndex := func(key Any) uint64 { return uint64(key.(string)[0] - 'A') }
+
+	// the jump hash of consecutive small integers is not a bijection: use many more shards than accounts
+	got := NewStreamFromSlice(getAccountEventsSample(numAccounts, numEventsPerAccount), 10).
+		MapByKeyWithHash(accountEvent.Account, accountIndex, numAccounts*10, slowIdentity).
+		Count()
+
+	end := time.Now()
+
+	assert.Equal(t, numAccounts*numEventsPerAccount, got)
+
+	// if concurrency is not effective, the test will take 15 seconds.
+	assert.WithinDuration(t, end, start, 5*time.Second)
+}
+
+func TestStream_MapByKeyWithHash(t *testing.T) {
+	constantHash := func(Any) uint64 { return 42 }
+
+	events := getAccountEventsSample(4, 5)
+
+	jitteryIdentity := func(e accountEvent) Any {
+		time.Sleep(time.Duration(e.seq%3) * time.Millisecond)
+		return e
+	}
+
+	got := C(NewStreamFromSlice(events, 0).
+		MapByKeyWithHash(accountEvent.Account, constantHash, 8, jitteryIdentity), accountEvent{}).
+		ToSlice()
+
+	// all keys are in the same shard: the order of the stream is preserved.
+	assert.Equal(t, events, got)
+}
+
+func TestFNVKeyHash(t *testing.T) {
+	assert.Equal(t, FNVKeyHash("abc"), FNVKeyHash("abc"))
+	assert.NotEqual(t, FNVKeyHash("abc"), FNVKeyHash("abd"))
+	assert.NotEqual(t, FNVKeyHash(1), FNVKeyHash("1"))
+}
+
+func TestJumpHash(t *testing.T) {
+	const numKeys = 10000
+
+	for _, buckets := range []int{1, 2, 7, 100} {
+		for key := uint64(0); key < numKeys; key++ {
+			b := jumpHash(FNVKeyHash(key), buckets)
+			assert.GreaterOrEqual(t, b, 0)
+			assert.Less(t, b, buckets)
+		}
+	}
+
+	// consistency: growing from 10 to 11 buckets should move about 1/11th of the keys.
+	moved := 0
+
+	for key := uint64(0); key < numKeys; key++ {
+		if jumpHash(FNVKeyHash(key), 10) != jumpHash(FNVKeyHash(key), 11) {
+			moved++
+		}
+	}
+
+	assert.InDelta(t, numKeys/11, moved, numKeys/50)
+}
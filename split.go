@@ -0,0 +1,121 @@
+package fuego
+
+// NOTICE:
+// All the methods in this file split a Stream into several output Streams.
+//
+// Each output Stream is independently consumable, in its own Go routine.
+//
+// Back-pressure policy: the output Streams are fed from a single Go routine
+// that reads the source Stream. The channel of each output Stream has the same
+// buffer capacity as the source Stream. Should an output Stream stop being read,
+// its buffer fills up and then the feeding Go routine blocks: ALL the output
+// Streams stall until the output Stream is read again. Elements are never dropped.
+//
+// Consequently, all the output Streams must be consumed (concurrently) until they
+// are closed. To discard an output Stream, consume it with e.g. ForEach(func(T) {}).
+
+// Partition splits this Stream into two Streams: the first one consists of the
+// elements that satisfy the predicate and the second one of the elements that
+// do not.
+//
+// See the back-pressure policy at the top of this file.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-streams will be closed too.
+func (s Stream[T]) Partition(p Predicate[T]) (Stream[T], Stream[T]) {
+	outstreams := s.split("Partition", 2, func(val T, outstreams []chan T) {
+		if p(val) {
+			outstreams[0] <- val
+			return
+		}
+
+		outstreams[1] <- val
+	})
+
+	return outstreams[0], outstreams[1]
+}
+
+// Tee broadcasts every element of this Stream to n independent Streams.
+//
+// The elements are not copied: when T is a reference type (pointer, slice, map...),
+// all the Streams share the same underlying data.
+//
+// An empty slice is returned when n < 1.
+//
+// See the back-pressure policy at the top of this file.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-streams will be closed too.
+func (s Stream[T]) Tee(n int) []Stream[T] {
+	if n < 1 {
+		return []Stream[T]{}
+	}
+
+	return s.split("Tee", n, func(val T, outstreams []chan T) {
+		for _, outstream := range outstreams {
+			outstream <- val
+		}
+	})
+}
+
+// Route sends each element of this Stream to the output Stream of the first
+// predicate it satisfies. Elements that do not satisfy any predicate are sent
+// to the default output Stream.
+//
+// The output Streams are returned in the order of the predicates, followed by
+// the default output Stream.
+//
+// See the back-pressure policy at the top of this file.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-streams will be closed too.
+func (s Stream[T]) Route(predicates ...Predicate[T]) ([]Stream[T], Stream[T]) {
+	outstreams := s.split("Route", len(predicates)+1, func(val T, outstreams []chan T) {
+		for idx, p := range predicates {
+			if p(val) {
+				outstreams[idx] <- val
+				return
+			}
+		}
+
+		outstreams[len(predicates)] <- val
+	})
+
+	return outstreams[:len(predicates)], outstreams[len(predicates)]
+}
+
+// split creates n output Streams and feeds them with the elements
+// of this Stream as directed by the dispatch function.
+// operation is the name of the method that splits the Stream, see Describe.
+func (s Stream[T]) split(operation string, n int, dispatch func(T, []chan T)) []Stream[T] {
+	s = s.Async()
+
+	outchannels := make([]chan T, n)
+	outstreams := make([]Stream[T], n)
+	input := s.Describe() // the output Streams share their input stage.
+
+	for idx := range outchannels {
+		outchannels[idx] = make(chan T, cap(s.stream))
+		outstreams[idx] = derivedStream(s, outchannels[idx]).planned(operation, s.name, input)
+		// releasing one output Stream must not affect its siblings.
+		outstreams[idx].releaser = nil
+	}
+
+	go func() {
+		defer func() {
+			for _, outstream := range outchannels {
+				close(outstream)
+			}
+		}()
+
+		if s.stream == nil {
+			return
+		}
+
+		for val := range s.stream {
+			dispatch(val, outchannels)
+		}
+	}()
+
+	return outstreams
+}
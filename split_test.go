@@ -0,0 +1,150 @@
+package fuego
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// toSlices concurrently consumes all the supplied streams into slices.
+func toSlices[T any](streams ...Stream[T]) [][]T {
+	results := make([][]T, len(streams))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(streams))
+
+	for idx, s := range streams {
+		idx, s := idx, s
+
+		go func() {
+			defer wg.Done()
+			results[idx] = s.ToSlice()
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func TestStream_Partition(t *testing.T) {
+	tt := map[string]struct {
+		stream          Stream[int]
+		wantMatching    []int
+		wantNonMatching []int
+	}{
+		"Should return empty streams when nil in-stream": {
+			stream:          Stream[int]{stream: nil},
+			wantMatching:    []int{},
+			wantNonMatching: []int{},
+		},
+		"Should return empty streams when empty in-stream": {
+			stream:          NewStreamFromSlice([]int{}, 0),
+			wantMatching:    []int{},
+			wantNonMatching: []int{},
+		},
+		"Should partition the in-stream": {
+			stream:          NewStreamFromSlice([]int{1, 7, 3, 9, 2, 6, 8}, 0),
+			wantMatching:    []int{7, 9, 6, 8},
+			wantNonMatching: []int{1, 3, 2},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			matching, nonMatching := tc.stream.Partition(intGreaterThanPredicate(5))
+
+			got := toSlices(matching, nonMatching)
+			assert.Equal(t, tc.wantMatching, got[0])
+			assert.Equal(t, tc.wantNonMatching, got[1])
+		})
+	}
+}
+
+func TestStream_Tee(t *testing.T) {
+	tt := map[string]struct {
+		stream Stream[int]
+		n      int
+		want   [][]int
+	}{
+		"Should return no stream when n is 0": {
+			stream: NewStreamFromSlice([]int{1, 2, 3}, 0),
+			n:      0,
+			want:   [][]int{},
+		},
+		"Should return empty streams when nil in-stream": {
+			stream: Stream[int]{stream: nil},
+			n:      2,
+			want:   [][]int{{}, {}},
+		},
+		"Should broadcast all elements to all streams": {
+			stream: NewStreamFromSlice([]int{1, 2, 3}, 0),
+			n:      3,
+			want:   [][]int{{1, 2, 3}, {1, 2, 3}, {1, 2, 3}},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := toSlices(tc.stream.Tee(tc.n)...)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStream_Tee_InheritsConcurrency(t *testing.T) {
+	streams := NewStreamFromSlice([]int{1, 2, 3}, 0).Concurrent(3).Tee(2)
+	for _, s := range streams {
+		assert.Equal(t, 3, s.Concurrency())
+	}
+
+	_ = toSlices(streams...)
+}
+
+func TestStream_Route(t *testing.T) {
+	isNegative := func(i int) bool { return i < 0 }
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	tt := map[string]struct {
+		stream      Stream[int]
+		predicates  []Predicate[int]
+		wantRoutes  [][]int
+		wantDefault []int
+	}{
+		"Should return empty streams when nil in-stream": {
+			stream:      Stream[int]{stream: nil},
+			predicates:  []Predicate[int]{isNegative, isEven},
+			wantRoutes:  [][]int{{}, {}},
+			wantDefault: []int{},
+		},
+		"Should send all elements to the default stream when no predicate": {
+			stream:      NewStreamFromSlice([]int{1, 2, 3}, 0),
+			predicates:  nil,
+			wantRoutes:  [][]int{},
+			wantDefault: []int{1, 2, 3},
+		},
+		"Should route elements to the first matching stream": {
+			stream:      NewStreamFromSlice([]int{-2, -1, 0, 1, 2, 3, 4}, 0),
+			predicates:  []Predicate[int]{isNegative, isEven},
+			wantRoutes:  [][]int{{-2, -1}, {0, 2, 4}},
+			wantDefault: []int{1, 3},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			routes, defaultRoute := tc.stream.Route(tc.predicates...)
+
+			got := toSlices(append(routes, defaultRoute)...)
+			assert.Equal(t, tc.wantRoutes, got[:len(routes)])
+			assert.Equal(t, tc.wantDefault, got[len(routes)])
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package fuego
+
+import (
+	"math"
+	"reflect"
+)
+
+// Stats is a summary of the numbers of a stream: count, sum, min, max, mean, variance
+// and standard deviation.
+//
+// The mean and the variance are computed in float64 with Welford's online algorithm,
+// which is numerically stable. Complex numbers are not ordered: for complex types, Min,
+// Max, Mean and the variance relate to the real part of the numbers.
+//
+// The zero value is the summary of an empty stream.
+//
+// See Summarizing and MathableStream.Stats.
+type Stats[T Mathable] struct {
+	// Count is the number of elements.
+	Count int
+	// Sum is the sum of the elements.
+	Sum T
+	// Min is the smallest element. It is the zero value of T when Count is 0.
+	Min T
+	// Max is the largest element. It is the zero value of T when Count is 0.
+	Max T
+	// Mean is the arithmetic mean of the elements. It is 0 when Count is 0.
+	Mean float64
+
+	// m2 is the sum of the squares of the differences from the mean.
+	m2 float64
+}
+
+// Variance returns the population variance of the elements (i.e. the mean of the
+// squares of their differences from the mean). It is 0 when Count is 0.
+func (s Stats[T]) Variance() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+
+	return s.m2 / float64(s.Count)
+}
+
+// SampleVariance returns the unbiased sample variance of the elements (i.e. Bessel's
+// correction is applied). It is 0 when Count is less than 2.
+func (s Stats[T]) SampleVariance() float64 {
+	if s.Count < 2 {
+		return 0
+	}
+
+	return s.m2 / float64(s.Count-1)
+}
+
+// StdDev returns the population standard deviation of the elements.
+func (s Stats[T]) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// SampleStdDev returns the sample standard deviation of the elements.
+func (s Stats[T]) SampleStdDev() float64 {
+	return math.Sqrt(s.SampleVariance())
+}
+
+// Add returns the summary of the elements of this summary and of val.
+func (s Stats[T]) Add(val T) Stats[T] {
+	x := toFloat64(val)
+
+	if s.Count == 0 || x < toFloat64(s.Min) {
+		s.Min = val
+	}
+
+	if s.Count == 0 || x > toFloat64(s.Max) {
+		s.Max = val
+	}
+
+	s.Count++
+	s.Sum += val
+
+	delta := x - s.Mean
+	s.Mean += delta / float64(s.Count)
+	s.m2 += delta * (x - s.Mean)
+
+	return s
+}
+
+// Combine returns the summary of the elements of this summary and of the other summary.
+// It is useful to merge the partial summaries of distinct streams or shards.
+func (s Stats[T]) Combine(other Stats[T]) Stats[T] {
+	if other.Count == 0 {
+		return s
+	}
+
+	if s.Count == 0 {
+		return other
+	}
+
+	if toFloat64(other.Min) < toFloat64(s.Min) {
+		s.Min = other.Min
+	}
+
+	if toFloat64(other.Max) > toFloat64(s.Max) {
+		s.Max = other.Max
+	}
+
+	count := float64(s.Count + other.Count)
+	delta := other.Mean - s.Mean
+
+	s.Mean += delta * float64(other.Count) / count
+	s.m2 += other.m2 + delta*delta*float64(s.Count)*float64(other.Count)/count
+	s.Count += other.Count
+	s.Sum += other.Sum
+
+	return s
+}
+
+// Summarizing returns a concurrent collector that produces the Stats of the input elements.
+func Summarizing[T Mathable]() Collector[T, Stats[T], Stats[T]] {
+	supplier := func() Stats[T] {
+		return Stats[T]{}
+	}
+
+	accumulator := func(s Stats[T], val T) Stats[T] {
+		return s.Add(val)
+	}
+
+	combiner := func(s1, s2 Stats[T]) Stats[T] {
+		return s1.Combine(s2)
+	}
+
+	return NewConcurrentCollector(supplier, accumulator, combiner, IdentityFinisher[Stats[T]])
+}
+
+// toFloat64 converts val to a float64. Complex numbers are converted to their real part.
+func toFloat64[T Mathable](val T) float64 {
+	switch v := any(val).(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	}
+
+	// other types, including those defined with a Mathable underlying type.
+	rv := reflect.ValueOf(val)
+
+	switch rv.Kind() { // nolint: exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	default:
+		return real(rv.Complex())
+	}
+}
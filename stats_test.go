@@ -0,0 +1,102 @@
+package fuego
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type celsius float32
+
+func TestStats(t *testing.T) {
+	s := Stats[celsius]{}.Add(21.5).Add(-3).Add(10)
+
+	assert.Equal(t, 3, s.Count)
+	assert.Equal(t, celsius(28.5), s.Sum)
+	assert.Equal(t, celsius(-3), s.Min)
+	assert.Equal(t, celsius(21.5), s.Max)
+	assert.InDelta(t, 9.5, s.Mean, 1e-9)
+	assert.InDelta(t, 300.5/3, s.Variance(), 1e-9)
+	assert.InDelta(t, 150.25, s.SampleVariance(), 1e-9)
+	assert.InDelta(t, math.Sqrt(300.5/3), s.StdDev(), 1e-9)
+	assert.InDelta(t, math.Sqrt(150.25), s.SampleStdDev(), 1e-9)
+}
+
+func TestStats_Empty(t *testing.T) {
+	s := Stats[int]{}
+
+	assert.Equal(t, 0, s.Count)
+	assert.Equal(t, 0.0, s.Mean)
+	assert.Equal(t, 0.0, s.Variance())
+	assert.Equal(t, 0.0, s.SampleVariance())
+	assert.Equal(t, 0.0, s.StdDev())
+	assert.Equal(t, 0.0, Stats[int]{}.Add(7).SampleVariance())
+}
+
+func TestStats_Combine(t *testing.T) {
+	rnd := seededRand()
+
+	values := make([]float64, 1000)
+	for idx := range values {
+		values[idx] = rnd.NormFloat64()*25 + 1e6
+	}
+
+	want := Stats[float64]{}
+	for _, v := range values {
+		want = want.Add(v)
+	}
+
+	tt := map[string][]int{
+		"Should combine an empty summary":       {0},
+		"Should combine a single summary":       {},
+		"Should combine two partial summaries":  {400},
+		"Should combine many partial summaries": {1, 10, 100, 500, 999},
+		"Should combine unbalanced summaries":   {999},
+	}
+
+	for name, cuts := range tt {
+		cuts := cuts
+
+		t.Run(name, func(t *testing.T) {
+			got := Stats[float64]{}
+			from := 0
+
+			for _, to := range append(cuts, len(values)) {
+				partial := Stats[float64]{}
+				for _, v := range values[from:to] {
+					partial = partial.Add(v)
+				}
+
+				got = got.Combine(partial)
+				from = to
+			}
+
+			assert.Equal(t, want.Count, got.Count)
+			assert.InDelta(t, want.Sum, got.Sum, 1e-3)
+			assert.Equal(t, want.Min, got.Min)
+			assert.Equal(t, want.Max, got.Max)
+			assert.InDelta(t, want.Mean, got.Mean, 1e-6)
+			assert.InDelta(t, want.Variance(), got.Variance(), 1e-6)
+			assert.InDelta(t, 25, got.StdDev(), 2)
+		})
+	}
+}
+
+func TestCollector_Summarizing(t *testing.T) {
+	c := Summarizing[uint8]()
+	assert.True(t, c.IsConcurrent())
+
+	got := Collect(NewStreamFromSlice([]uint8{255, 0, 128}, 0), c)
+	assert.Equal(t, 3, got.Count)
+	assert.Equal(t, uint8(127), got.Sum) // overflows, as T does.
+	assert.Equal(t, uint8(0), got.Min)
+	assert.Equal(t, uint8(255), got.Max)
+	assert.InDelta(t, 383.0/3, got.Mean, 1e-9)
+
+	gotComplex := Collect(NewStreamFromSlice([]complex64{1 + 1i, 3 - 1i}, 0), Summarizing[complex64]())
+	assert.Equal(t, complex64(4), gotComplex.Sum)
+	assert.Equal(t, complex64(1+1i), gotComplex.Min)
+	assert.Equal(t, complex64(3-1i), gotComplex.Max)
+	assert.InDelta(t, 2, gotComplex.Mean, 1e-9)
+}
@@ -4,9 +4,9 @@ package fuego
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/google/go-cmp/cmp"
-	"go.uber.org/zap"
 )
 
 // Stream is a sequence of elements supporting sequential and
@@ -14,6 +14,10 @@ import (
 //
 // A Stream is a wrapper over a Go channel ('nil' channels are prohibited).
 //
+// Streams created from a slice or a pull-style iterator are synchronous: they wrap a
+// function rather than a channel until they reach a concurrent stage or an explicit
+// asynchronous boundary. See Stream.Async and pull.go.
+//
 // NOTE:
 //
 // Concurrent streams are challenging to implement owing to
@@ -35,7 +39,19 @@ import (
 // Streams created from a slice are bounded since the slice has finite content.
 type Stream[T any] struct {
 	stream      chan T
+	pull        func() (T, bool)
+	pullLock    *sync.Mutex
+	bufsize     int
 	concurrency int
+	batchSize   int
+	releaser    *releaser
+	failure     *failure
+	fused       *fusedStage[T]
+	name        string
+	metrics     Metrics
+	observer    Observer
+	logger      Logger
+	plan        *PlanNode
 }
 
 // NewStream creates a new Stream.
@@ -50,24 +66,51 @@ func NewConcurrentStream[T any](c chan T, n int) Stream[T] {
 	return Stream[T]{
 		stream:      c,
 		concurrency: n,
+		failure:     newFailure(),
+	}.planned("NewStream", "")
+}
+
+// derivedStream creates a new Stream over channel c that inherits
+// the settings (concurrency, etc) of Stream s.
+func derivedStream[T, U any](s Stream[T], c chan U) Stream[U] {
+	return Stream[U]{
+		stream:      c,
+		bufsize:     s.bufsize,
+		concurrency: s.concurrency,
+		batchSize:   s.batchSize,
+		releaser:    s.releaser,
+		failure:     s.failure,
+		metrics:     s.metrics,
+		observer:    s.observer,
+		logger:      s.logger,
+		plan:        s.plan,
 	}
 }
 
 // NewStreamFromSlice creates a new Stream from a Go slice.
 //
 // The slice data is published to the stream after which the stream is closed.
+//
+// The stream is synchronous: its non-concurrent Filter, Map, Peek, Take and Drop
+// stages are executed as function calls, without Go routines nor channels.
+// bufsize is the capacity of the channel created at the first asynchronous stage,
+// if any. See Stream.Async.
+//
+// The stream may be consumed by several Go routines concurrently, as a channel would:
+// each element is then delivered to only one of them. See pull.go.
 func NewStreamFromSlice[T any](slice []T, bufsize int) Stream[T] {
-	c := make(chan T, bufsize)
-
-	go func() {
-		defer close(c) // slices have finite size: close stream after all data was read.
+	idx := 0
 
-		for _, element := range slice {
-			c <- element
+	return newPullStream(func() (T, bool) {
+		if idx == len(slice) { // slices have finite size: close stream after all data was read.
+			var t T
+			return t, false
 		}
-	}()
 
-	return NewStream(c)
+		idx++
+
+		return slice[idx-1], true
+	}, bufsize).planned("NewStreamFromSlice", "")
 }
 
 // Concurrency returns the stream's concurrency level (i.e. parallelism).
@@ -96,12 +139,46 @@ func (s Stream[T]) Concurrency() int {
 //
 // If latency is too low or next to none, using concurrency will
 // likely be slower than without, particularly when no CPU core is
-// available.
+// available. See MicroBatch to amortise the cost of concurrency when
+// latency is low.
+//
+// Note that non-concurrent Filter, Map, Peek, Take and Drop stages of
+// synchronous streams (e.g. created with NewStreamFromSlice) do not use
+// channels at all. See Stream.Async.
 func (s Stream[T]) Concurrent(n int) Stream[T] {
 	// This is not accurate but improves performance (by avoiding the
 	// creation of a new channel and iterating through this one).
 	// It should be safe.
-	return NewConcurrentStream(s.stream, n)
+	s.concurrency = n
+	return s
+}
+
+// MicroBatchSize returns the number of elements handed over at once to
+// the workers of concurrent methods such as Stream.Map.
+func (s Stream[T]) MicroBatchSize() int {
+	if s.batchSize < 1 {
+		return 1
+	}
+
+	return s.batchSize
+}
+
+// MicroBatch sets the number of elements handed over at once to the
+// workers of concurrent methods such as Stream.Map.
+//
+// Handing over elements one at a time costs several channel operations
+// per element. When the latency of the mapper is very low, these costs
+// dominate and grouping n elements per hand-off amortises them.
+//
+// A micro-batch is handed over as soon as it is full or as soon as no
+// further element is immediately available on the stream, whichever
+// comes first. Hence, micro-batching does not hold back elements from
+// a slow producer.
+//
+// Order is preserved. n < 1 is the same as n = 1 (i.e. no batching).
+func (s Stream[T]) MicroBatch(n int) Stream[T] {
+	s.batchSize = n
+	return s
 }
 
 // Any is an alias for type `any`.
@@ -113,103 +190,317 @@ type Any any
 // This function streams continuously until the in-stream is closed at
 // which point the out-stream will be closed too.
 func (s Stream[T]) Map(mapper Function[T, Any]) Stream[Any] {
-	return NewConcurrentStream(orderlyConcurrentDo(s, mapper), s.concurrency)
+	return Map[T, Any](s, mapper)
 }
 
 // orderlyConcurrentDo executes a Function on the stream.
 // Execution is concurrent and order is preserved.
 // See note on method Map() about the lack of support for parameterised methods in Go.
-func orderlyConcurrentDo[T, U any](s Stream[T], fn Function[T, U]) chan U {
+//
+// A fixed pool of Concurrency() workers (at least one) processes micro-batches
+// of elements held in a ring of reusable slots. The slots are handed over to the
+// workers and to the reader in the same sequence, which is how order is preserved.
+//
+// h, which may be nil, is notified of the activity of the stage.
+func orderlyConcurrentDo[T, U any](s Stream[T], h *stageHooks, fn Function[T, U]) chan U {
+	fn = instrumentInput(h, instrumentFunction(h, fn))
+	s = s.Async()
+
 	outstream := make(chan U, cap(s.stream))
 
 	go func() {
 		defer close(outstream)
+		defer h.close()
+
+		h.start()
 
 		if s.stream == nil {
 			return
 		}
 
-		pipelineCh := make(chan chan U, s.concurrency)
+		workers := s.concurrency
+		if workers < 1 {
+			workers = 1
+		}
 
-		pipelineWriter := func(pipelineWCh chan chan U) {
-			defer close(pipelineWCh)
+		batchSize := s.MicroBatchSize()
 
-			for val := range s.stream {
-				resultCh := make(chan U, 1)
-				pipelineWCh <- resultCh
+		ring := make([]orderlySlot[T, U], 2*workers)
+		freeSlots := make(chan int, len(ring))
+		for idx := range ring {
+			ring[idx].done = make(chan struct{}, 1)
+			freeSlots <- idx
+		}
 
-				go func(resultCh chan<- U, val T) {
-					defer close(resultCh)
-					resultCh <- fn(val)
-				}(resultCh, val)
-			}
+		jobs := make(chan int, len(ring))
+		sequence := make(chan int, len(ring))
+
+		for i := 0; i < workers; i++ {
+			go func() {
+				for idx := range jobs {
+					slot := &ring[idx]
+					s.failure.run(func() {
+						for _, val := range slot.in {
+							slot.out = append(slot.out, fn(val))
+						}
+					})
+					slot.done <- struct{}{}
+				}
+			}()
 		}
 
 		go func() {
-			pipelineWriter(pipelineCh)
+			defer close(sequence)
+			defer close(jobs)
+
+			for {
+				val, ok := s.receiveUntilFailed()
+				if !ok {
+					return
+				}
+
+				idx := <-freeSlots
+				slot := &ring[idx]
+				slot.in = append(slot.in, val)
+				more := slot.fill(s.stream, batchSize)
+
+				jobs <- idx
+				sequence <- idx
+
+				if !more {
+					return
+				}
+			}
 		}()
 
-		pipelineReader := func(pipelineRCh chan chan U) {
-			for resultCh := range pipelineRCh {
-				outstream <- <-resultCh
+		for idx := range sequence {
+			slot := &ring[idx]
+			<-slot.done
+
+			if !s.failure.hasFailed() {
+				for _, val := range slot.out {
+					send(h, outstream, val)
+				}
 			}
+
+			slot.reset()
+			freeSlots <- idx
+		}
+
+		if s.failure.hasFailed() {
+			s.release()
 		}
-		pipelineReader(pipelineCh)
 	}()
 
 	return outstream
 }
 
+// orderlySlot is a reusable slot of the ring used by orderlyConcurrentDo.
+type orderlySlot[T, U any] struct {
+	in   []T
+	out  []U
+	done chan struct{}
+}
+
+// fill adds to the slot the elements that are immediately available on the
+// channel, up to a total of batchSize elements.
+// It returns false when the channel has been closed.
+func (slot *orderlySlot[T, U]) fill(c chan T, batchSize int) bool {
+	for len(slot.in) < batchSize {
+		select {
+		case val, ok := <-c:
+			if !ok {
+				return false
+			}
+			slot.in = append(slot.in, val)
+		default:
+			return true
+		}
+	}
+
+	return true
+}
+
+// reset empties the slot while retaining its allocated memory.
+func (slot *orderlySlot[T, U]) reset() {
+	var zeroT T
+	for i := range slot.in {
+		slot.in[i] = zeroT // release references for the GC
+	}
+
+	var zeroU U
+	for i := range slot.out {
+		slot.out[i] = zeroU // release references for the GC
+	}
+
+	slot.in = slot.in[:0]
+	slot.out = slot.out[:0]
+}
+
 // FlatMap takes a StreamFunction to flatten the entries
 // in this stream and produce a new stream.
 //
 // This function streams continuously until the in-stream is closed at
 // which point the out-stream will be closed too.
 func (s Stream[T]) FlatMap(mapper StreamFunction[T, Any]) Stream[Any] {
-	return NewConcurrentStream(orderlyConcurrentDoStream(s, mapper), s.concurrency)
+	return FlatMap[T, Any](s, mapper)
 }
 
 // orderlyConcurrentDoStream executes a StreamFunction on the stream.
 // Execution is concurrent and order is preserved.
-func orderlyConcurrentDoStream[T, U any](s Stream[T], streamfn StreamFunction[T, U]) chan U {
+//
+// h, which may be nil, is notified of the activity of the stage.
+func orderlyConcurrentDoStream[T, U any](s Stream[T], h *stageHooks, streamfn StreamFunction[T, U]) chan U {
+	fn := instrumentInput(h, instrumentFunction(h, Function[T, Stream[U]](streamfn)))
+	s = s.Async()
+
 	outstream := make(chan U, cap(s.stream))
 
 	go func() {
 		defer close(outstream)
+		defer h.close()
+
+		h.start()
 
 		if s.stream == nil {
 			return
 		}
 
-		pipelineCh := make(chan chan Stream[U], s.concurrency)
+		for val := range orderlyConcurrentDo(s, nil, fn) {
+			val.ForEach(func(e U) {
+				send(h, outstream, e)
+			})
+		}
+	}()
+
+	return outstream
+}
+
+// MapUnordered returns a Stream consisting of the result of
+// applying the given function to the elements of this stream.
+//
+// Unlike Map, MapUnordered does not preserve the order of the elements.
+// Exactly Concurrency() workers (at least one) apply the mapper and each
+// result is emitted as soon as it is available. This avoids head-of-line
+// blocking when the latency of the mapper varies from one element to another.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func (s Stream[T]) MapUnordered(mapper Function[T, Any]) Stream[Any] {
+	return MapUnordered[T, Any](s, mapper)
+}
 
-		pipelineWriter := func(pipelineWCh chan chan Stream[U]) {
-			defer close(pipelineWCh)
+// unorderedConcurrentDo executes a Function on the stream.
+// Execution is concurrent and order is NOT preserved.
+//
+// h, which may be nil, is notified of the activity of the stage.
+func unorderedConcurrentDo[T, U any](s Stream[T], h *stageHooks, fn Function[T, U]) chan U {
+	fn = instrumentInput(h, instrumentFunction(h, fn))
+	s = s.Async()
 
-			for val := range s.stream {
-				resultCh := make(chan Stream[U], 1)
-				pipelineWCh <- resultCh
+	outstream := make(chan U, cap(s.stream))
 
-				go func(resultCh chan<- Stream[U], val T) {
-					defer close(resultCh)
-					resultCh <- streamfn(val)
-				}(resultCh, val)
-			}
+	go func() {
+		defer close(outstream)
+		defer h.close()
+
+		h.start()
+
+		if s.stream == nil {
+			return
 		}
 
-		go func() {
-			pipelineWriter(pipelineCh)
-		}()
+		workers := s.concurrency
+		if workers < 1 {
+			workers = 1
+		}
 
-		pipelineReader := func(pipelineRCh chan chan Stream[U]) {
-			for resultCh := range pipelineRCh {
-				val := <-resultCh
-				val.ForEach(func(e U) {
-					outstream <- e
+		wg := sync.WaitGroup{}
+		wg.Add(workers)
+
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer wg.Done()
+
+				s.failure.run(func() {
+					for val, ok := s.receiveUntilFailed(); ok; val, ok = s.receiveUntilFailed() {
+						send(h, outstream, fn(val))
+					}
 				})
-			}
+			}()
+		}
+
+		wg.Wait()
+
+		if s.failure.hasFailed() {
+			s.release()
+		}
+	}()
+
+	return outstream
+}
+
+// FlatMapUnordered takes a StreamFunction to flatten the entries
+// in this stream and produce a new stream.
+//
+// Unlike FlatMap, FlatMapUnordered does not preserve the order of the elements.
+// Exactly Concurrency() workers (at least one) apply the mapper and the elements
+// of the resulting streams are emitted as soon as they are available. Elements
+// produced from different input elements may therefore be interleaved.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func (s Stream[T]) FlatMapUnordered(mapper StreamFunction[T, Any]) Stream[Any] {
+	return FlatMapUnordered[T, Any](s, mapper)
+}
+
+// unorderedConcurrentDoStream executes a StreamFunction on the stream.
+// Execution is concurrent and order is NOT preserved.
+//
+// h, which may be nil, is notified of the activity of the stage.
+func unorderedConcurrentDoStream[T, U any](s Stream[T], h *stageHooks, streamfn StreamFunction[T, U]) chan U {
+	fn := instrumentInput(h, instrumentFunction(h, Function[T, Stream[U]](streamfn)))
+	s = s.Async()
+
+	outstream := make(chan U, cap(s.stream))
+
+	go func() {
+		defer close(outstream)
+		defer h.close()
+
+		h.start()
+
+		if s.stream == nil {
+			return
+		}
+
+		workers := s.concurrency
+		if workers < 1 {
+			workers = 1
+		}
+
+		wg := sync.WaitGroup{}
+		wg.Add(workers)
+
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer wg.Done()
+
+				s.failure.run(func() {
+					for val, ok := s.receiveUntilFailed(); ok; val, ok = s.receiveUntilFailed() {
+						fn(val).ForEach(func(e U) {
+							send(h, outstream, e)
+						})
+					}
+				})
+			}()
+		}
+
+		wg.Wait()
+
+		if s.failure.hasFailed() {
+			s.release()
 		}
-		pipelineReader(pipelineCh)
 	}()
 
 	return outstream
@@ -218,40 +509,63 @@ func orderlyConcurrentDoStream[T, U any](s Stream[T], streamfn StreamFunction[T,
 // Filter returns a stream consisting of the elements of this stream that
 // match the given predicate.
 //
+// When the stream is concurrent, the predicate is evaluated concurrently
+// and the order of the elements is preserved.
+//
 // This function streams continuously until the in-stream is closed at
 // which point the out-stream will be closed too.
 func (s Stream[T]) Filter(predicate Predicate[T]) Stream[T] {
+	h := s.hooks("Filter")
+	predicate = instrumentPredicate(h, predicate, false)
+
+	if s.concurrency == 0 {
+		return planStage(s, fuse(s, h, func(pull func() (T, bool)) func() (T, bool) {
+			return pullFilter(pull, predicate)
+		}), "Filter")
+	}
+
+	s = s.Async()
 	outstream := make(chan T, cap(s.stream))
 
 	go func() {
 		defer close(outstream)
+		defer h.close()
 
-		if s.stream == nil {
-			return
-		}
+		h.start()
 
-		for val := range s.stream {
-			if predicate(val) {
-				outstream <- val
+		evaluate := instrumentInput(h, func(val T) evaluatedElement[T, bool] {
+			return evaluatedElement[T, bool]{value: val, result: predicate(val)}
+		})
+
+		for e := range orderlyConcurrentDo(s, nil, evaluate) {
+			if e.result {
+				send(h, outstream, e.value)
 			}
 		}
 	}()
 
-	return NewConcurrentStream(outstream, s.concurrency)
+	return planStage(s, derivedStream(s, outstream), "Filter")
+}
+
+// evaluatedElement holds an element of a stream alongside the
+// result of a function evaluated against it.
+type evaluatedElement[T, R any] struct {
+	value  T
+	result R
 }
 
 // LeftReduce accumulates the elements of this Stream by applying the given function.
 //
 // This is a continuous terminal operation. It will only complete if the producer closes the stream.
 func (s Stream[T]) LeftReduce(f2 BiFunction[T, T, T]) T {
-	if s.stream == nil {
+	if s.isNil() {
 		var t T
 		return t // TODO: return Optional
 	}
 
-	res := <-s.stream
+	res, _ := s.receive()
 
-	for val := range s.stream {
+	for val, ok := s.receive(); ok; val, ok = s.receive() {
 		res = f2(res, val)
 	}
 
@@ -265,11 +579,37 @@ func (s Stream[T]) Reduce(f2 BiFunction[T, T, T]) T {
 	return s.LeftReduce(f2)
 }
 
+// ReduceOptional accumulates the elements of this Stream by applying the given function,
+// like LeftReduce does, and returns the result as an Optional.
+//
+// The Optional is empty when the stream is empty.
+//
+// This is a continuous terminal operation and hence expects the producer to close
+// the stream in order to complete.
+func (s Stream[T]) ReduceOptional(f2 BiFunction[T, T, T]) Optional[T] {
+	if s.isNil() {
+		panic(ErrMissingChannel)
+	}
+
+	res, ok := s.receive()
+	if !ok {
+		return OptionalEmpty[T]()
+	}
+
+	for val, ok := s.receive(); ok; val, ok = s.receive() {
+		res = f2(res, val)
+	}
+
+	return OptionalOf(res)
+}
+
 // Intersperse inserts an element between all elements of this Stream.
 //
 // This function streams continuously until the in-stream is closed at
 // which point the out-stream will be closed too.
 func (s Stream[T]) Intersperse(e T) Stream[T] {
+	s = s.Async()
+
 	outstream := make(chan T, cap(s.stream))
 
 	go func() {
@@ -294,7 +634,7 @@ func (s Stream[T]) Intersperse(e T) Stream[T] {
 		}
 	}()
 
-	return NewConcurrentStream(outstream, s.concurrency)
+	return planStage(s, derivedStream(s, outstream), "Intersperse")
 }
 
 // GroupBy groups the elements of this Stream by classifying them.
@@ -304,16 +644,14 @@ func (s Stream[T]) Intersperse(e T) Stream[T] {
 func (s Stream[T]) GroupBy(classifier Function[T, Any]) map[Any][]T {
 	resultMap := make(map[Any][]T)
 
-	if s.stream != nil {
-		for val := range s.stream {
-			k := classifier(val)
+	for val, ok := s.receive(); ok; val, ok = s.receive() {
+		k := classifier(val)
 
-			if resultMap[k] == nil {
-				resultMap[k] = []T{}
-			}
-
-			resultMap[k] = append(resultMap[k], val)
+		if resultMap[k] == nil {
+			resultMap[k] = []T{}
 		}
+
+		resultMap[k] = append(resultMap[k], val)
 	}
 
 	return resultMap
@@ -325,12 +663,8 @@ func (s Stream[T]) GroupBy(classifier Function[T, Any]) map[Any][]T {
 // the producer to close the stream in order to complete (or
 // it will block).
 func (s Stream[T]) Count() int {
-	if s.stream == nil {
-		return 0
-	}
-
 	count := 0
-	for range s.stream {
+	for _, ok := s.receive(); ok; _, ok = s.receive() {
 		count++
 	}
 
@@ -344,11 +678,11 @@ func (s Stream[T]) Count() int {
 // the producer to close the stream in order to complete (or
 // it will block).
 func (s Stream[T]) AllMatch(p Predicate[T]) bool {
-	if s.stream == nil {
+	if s.isNil() {
 		return false
 	}
 
-	for val := range s.stream {
+	for val, ok := s.receive(); ok; val, ok = s.receive() {
 		if !p(val) {
 			return false
 		}
@@ -364,11 +698,7 @@ func (s Stream[T]) AllMatch(p Predicate[T]) bool {
 // the producer to close the stream in order to complete (or
 // it will block).
 func (s Stream[T]) AnyMatch(p Predicate[T]) bool {
-	if s.stream == nil {
-		return false
-	}
-
-	for val := range s.stream {
+	for val, ok := s.receive(); ok; val, ok = s.receive() {
 		if p(val) {
 			return true
 		}
@@ -387,6 +717,133 @@ func (s Stream[T]) NoneMatch(p Predicate[T]) bool {
 	return !s.AnyMatch(p)
 }
 
+// FindFirst returns an Optional describing the first element of this stream,
+// or an empty Optional if the stream is empty.
+//
+// This is a short-circuiting terminal operation: the remaining elements of the
+// stream are released (see Stream.All).
+func (s Stream[T]) FindFirst() Optional[T] {
+	return s.FindFirstMatching(True[T]())
+}
+
+// FindFirstMatching returns an Optional describing the first element of this stream
+// that satisfies the predicate, or an empty Optional if there is none.
+//
+// This is a short-circuiting terminal operation: once an element is found, the
+// remaining elements of the stream are released (see Stream.All).
+func (s Stream[T]) FindFirstMatching(p Predicate[T]) Optional[T] {
+	if s.isNil() {
+		panic(ErrMissingChannel)
+	}
+
+	for val, ok := s.receive(); ok; val, ok = s.receive() {
+		if p(val) {
+			s.release()
+			return OptionalOf(val)
+		}
+	}
+
+	return OptionalEmpty[T]()
+}
+
+// FindAny returns an Optional describing any element of this stream that satisfies
+// the predicate, or an empty Optional if there is none.
+//
+// Unlike FindFirstMatching, the predicate is evaluated by Concurrency() workers (at
+// least one) and the element found is not necessarily the first one. This is
+// beneficial when the predicate has a significant latency.
+//
+// This is a short-circuiting terminal operation: once an element is found, the
+// workers stop reading the stream, without waiting for the predicates in progress,
+// and the remaining elements of the stream are released (see Stream.All).
+func (s Stream[T]) FindAny(p Predicate[T]) Optional[T] {
+	if s.isNil() {
+		panic(ErrMissingChannel)
+	}
+
+	s = s.Async()
+
+	workers := s.concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	// found is closed once an element is found: the workers then stop reading the stream.
+	found := make(chan struct{})
+	once := sync.Once{}
+
+	var (
+		result  T
+		matched bool
+	)
+
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			s.failure.run(func() {
+				for {
+					var (
+						val T
+						ok  bool
+					)
+
+					select {
+					case <-found:
+						return
+					case <-s.failure.failed():
+						return
+					case val, ok = <-s.stream:
+					}
+
+					if !ok {
+						return
+					}
+
+					select {
+					case <-found:
+						// another worker found an element while this one was receiving.
+						return
+					default:
+					}
+
+					if p(val) {
+						once.Do(func() {
+							result, matched = val, true
+							close(found)
+						})
+
+						return
+					}
+				}
+			})
+		}()
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		wg.Wait()
+	}()
+
+	select {
+	case <-found:
+	case <-done:
+		if !matched {
+			s.failure.resume()
+			return OptionalEmpty[T]()
+		}
+	}
+
+	s.release()
+
+	return OptionalOf(result)
+}
+
 // Drop the first 'n' elements of this stream and returns a new stream.
 //
 // This function streams continuously until the in-stream is closed at
@@ -407,32 +864,12 @@ func (s Stream[T]) Drop(n uint64) Stream[T] {
 // This function streams continuously until the in-stream is closed at
 // which point the out-stream will be closed too.
 func (s Stream[T]) DropWhile(p Predicate[T]) Stream[T] {
-	outstream := make(chan T, cap(s.stream))
+	h := s.hooks("DropWhile")
+	p = instrumentPredicate(h, p, true)
 
-	go func() {
-		defer close(outstream)
-
-		if s.stream == nil {
-			return
-		}
-
-		// drop elements as required
-		for val := range s.stream {
-			if p(val) {
-				continue
-			}
-			outstream <- val
-
-			break
-		}
-
-		// flush the remainder to outstream
-		for val := range s.stream {
-			outstream <- val
-		}
-	}()
-
-	return NewConcurrentStream(outstream, s.concurrency)
+	return planStage(s, fuse(s, h, func(pull func() (T, bool)) func() (T, bool) {
+		return pullDropWhile(pull, p)
+	}), "DropWhile")
 }
 
 // DropUntil drops the first elements of this stream until the predicate
@@ -452,24 +889,65 @@ func (s Stream[T]) Last() T {
 	return s.LastN(1)[0]
 }
 
+// LastOptional returns an Optional describing the last element of this stream,
+// or an empty Optional if the stream is empty.
+//
+// This is a continuous terminal operation and hence expects the producer to close
+// the stream in order to complete.
+func (s Stream[T]) LastOptional() Optional[T] {
+	if s.isNil() {
+		panic(ErrMissingChannel)
+	}
+
+	last, ok := s.receive()
+	if !ok {
+		return OptionalEmpty[T]()
+	}
+
+	for val, ok := s.receive(); ok; val, ok = s.receive() {
+		last = val
+	}
+
+	return OptionalOf(last)
+}
+
 // LastN returns a slice of the last n elements in this stream.
 //
+// It panics with ErrNoSuchElement when the stream is empty or n < 1.
+// See TryLastN for a variant that returns an error instead.
+//
 // This function streams continuously until the in-stream is closed at
 // which point the out-stream will be closed too.
 func (s Stream[T]) LastN(n uint64) []T {
+	result, err := s.TryLastN(n)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+// TryLastN returns a slice of the last n elements in this stream.
+//
+// Unlike LastN, it does not panic: it returns ErrMissingChannel when the stream
+// has no channel and ErrNoSuchElement when the stream is empty or n < 1.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func (s Stream[T]) TryLastN(n uint64) ([]T, error) {
 	const flushTriggerDefault = uint64(100)
 
-	if s.stream == nil {
-		panic(PanicMissingChannel)
+	if s.isNil() {
+		return nil, ErrMissingChannel
 	}
 
 	if n < 1 {
-		panic(PanicNoSuchElement)
+		return nil, ErrNoSuchElement
 	}
 
-	val, ok := <-s.stream
+	val, ok := s.receive()
 	if !ok {
-		panic(PanicNoSuchElement)
+		return nil, ErrNoSuchElement
 	}
 
 	result := []T{val}
@@ -481,7 +959,7 @@ func (s Stream[T]) LastN(n uint64) []T {
 		flushTrigger = n
 	}
 
-	for val = range s.stream {
+	for val, ok = s.receive(); ok; val, ok = s.receive() {
 		result = append(result, val)
 		if count++; count > flushTrigger {
 			// this is simply to reduce the number of
@@ -492,10 +970,10 @@ func (s Stream[T]) LastN(n uint64) []T {
 	}
 
 	if uint64(len(result)) > n {
-		return result[uint64(len(result))-n:]
+		return result[uint64(len(result))-n:], nil
 	}
 
-	return result
+	return result, nil
 }
 
 // Head returns the first Entry in this stream.
@@ -504,12 +982,19 @@ func (s Stream[T]) LastN(n uint64) []T {
 func (s Stream[T]) Head() T {
 	head := s.HeadN(1)
 	if len(head) != 1 {
-		panic(PanicNoSuchElement)
+		panic(ErrNoSuchElement)
 	}
 
 	return head[0]
 }
 
+// HeadOptional is an alias for FindFirst.
+//
+// See FindFirst for more info.
+func (s Stream[T]) HeadOptional() Optional[T] {
+	return s.FindFirst()
+}
+
 // HeadN returns a slice of the first n elements in this stream.
 //
 // This function only consumes at most 'n' elements from the stream.
@@ -552,24 +1037,16 @@ func (s Stream[T]) Limit(n uint64) Stream[T] {
 // This function streams continuously until the in-stream is closed at
 // which point the out-stream will be closed too.
 func (s Stream[T]) TakeWhile(p Predicate[T]) Stream[T] {
-	if s.stream == nil {
-		panic(PanicMissingChannel)
+	if s.isNil() {
+		panic(ErrMissingChannel)
 	}
 
-	outstream := make(chan T, cap(s.stream))
+	h := s.hooks("TakeWhile")
+	p = instrumentPredicate(h, p, false)
 
-	go func() {
-		defer close(outstream)
-
-		for val := range s.stream {
-			if !p(val) {
-				return
-			}
-			outstream <- val
-		}
-	}()
-
-	return NewConcurrentStream(outstream, s.concurrency)
+	return planStage(s, fuse(s, h, func(pull func() (T, bool)) func() (T, bool) {
+		return pullTakeWhile(pull, p)
+	}), "TakeWhile")
 }
 
 // TakeUntil returns a stream of the first elements
@@ -639,37 +1116,130 @@ func (s Stream[T]) EndsWith(slice []T) bool {
 
 // ForEach executes the given consumer function for each entry in this stream.
 //
+// The consumer is called sequentially, in the order of the stream, regardless of
+// the concurrency level of the stream: existing pipelines rely on this guarantee,
+// for instance to collect the results of a concurrent Map in order with a consumer
+// that is not safe for concurrent use. Use ForEachConcurrent to honour the
+// concurrency level of the stream, e.g. s.ForEachConcurrent(s.Concurrency(), c).
+//
 // This is a continuous terminal operation. It will only complete if the producer closes the stream.
 func (s Stream[T]) ForEach(c Consumer[T]) {
-	if s.stream == nil {
-		zap.L().Debug("empty stream")
+	s.forEach(0, c)
+}
+
+// ForEachConcurrent executes the given consumer function for each entry in this
+// stream using n concurrent workers (at least one).
+//
+// The consumer may be called concurrently and in any order. It must therefore
+// be safe for concurrent use.
+//
+// Should the consumer panic, the workers stop, the stream is released and the panic
+// is resumed in the calling Go routine.
+//
+// This is a continuous terminal operation. It will only complete if the producer
+// closes the stream and all the calls to the consumer have returned.
+func (s Stream[T]) ForEachConcurrent(n int, c Consumer[T]) {
+	if n < 1 {
+		n = 1
+	}
+
+	s.forEach(n, c)
+}
+
+// forEach executes the given consumer function for each entry in this stream
+// using n concurrent workers, or sequentially on the calling Go routine when n is 0.
+func (s Stream[T]) forEach(n int, c Consumer[T]) {
+	l := s.log()
+
+	if s.isNil() {
+		if l != nil {
+			l.Debug("empty stream")
+		}
+
 		return
 	}
 
-	for val := range s.stream {
-		zap.L().Debug("calling consumer", zap.Any("value", val))
-		c(val)
+	if l != nil {
+		consumer := c
+		c = func(val T) {
+			l.Debug("calling consumer", "value", val)
+			consumer(val)
+		}
+	}
+
+	if h := s.hooks("ForEach"); h != nil {
+		h.start()
+		defer h.close()
+
+		consumer := c
+		consume := instrumentInput(h, instrumentFunction(h, func(val T) struct{} {
+			consumer(val)
+			return struct{}{}
+		}))
+
+		c = func(val T) { consume(val) }
+	}
+
+	if n == 0 {
+		for val, ok := s.receive(); ok; val, ok = s.receive() {
+			c(val)
+		}
+
+		return
+	}
+
+	s = s.Async()
+
+	wg := sync.WaitGroup{}
+	wg.Add(n)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+
+			s.failure.run(func() {
+				for val, ok := s.receiveUntilFailed(); ok; val, ok = s.receiveUntilFailed() {
+					c(val)
+				}
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	if s.failure.hasFailed() {
+		s.release()
 	}
+
+	s.failure.resume()
 }
 
 // Peek is akin to ForEach but returns the Stream.
 //
 // This is useful e.g. for debugging.
 //
+// When the stream is concurrent, the consumer is called concurrently
+// and the order of the elements is preserved.
+//
 // This function streams continuously until the in-stream is closed at
 // which point the out-stream will be closed too.
 func (s Stream[T]) Peek(consumer Consumer[T]) Stream[T] {
-	outstream := make(chan T, cap(s.stream))
+	peek := func(e T) T {
+		consumer(e)
+		return e
+	}
 
-	go func() {
-		defer close(outstream)
-		s.ForEach(func(e T) {
-			consumer(e)
-			outstream <- e
-		})
-	}()
+	h := s.hooks("Peek")
+
+	if s.concurrency == 0 {
+		peek = instrumentFunction(h, peek)
 
-	return NewConcurrentStream(outstream, s.concurrency)
+		return planStage(s, fuse(s, h, func(pull func() (T, bool)) func() (T, bool) {
+			return pullMap(pull, peek)
+		}), "Peek")
+	}
+
+	return planStage(s, derivedStream(s, orderlyConcurrentDo(s, h, peek)), "Peek")
 }
 
 // ToSlice extracts the elements of the stream into a []T.
@@ -682,10 +1252,8 @@ func (s Stream[T]) Peek(consumer Consumer[T]) Stream[T] {
 func (s Stream[T]) ToSlice() []T {
 	result := []T{}
 
-	if s.stream != nil {
-		for val := range s.stream {
-			result = append(result, val)
-		}
+	for val, ok := s.receive(); ok; val, ok = s.receive() {
+		result = append(result, val)
 	}
 
 	return result
@@ -694,42 +1262,74 @@ func (s Stream[T]) ToSlice() []T {
 // Distinct returns a stream of the distinct elements of this stream.
 // Distinctiveness is determined via the provided hashFn.
 //
+// When the stream is concurrent, hashFn is evaluated concurrently
+// and the order of the elements is preserved.
+//
 // This operation is costly both in time and in memory. It is
 // strongly recommended to use buffered channels for this operation.
 //
 // This function streams continuously until the in-stream is closed at
 // which point the out-stream will be closed too.
 func (s Stream[T]) Distinct(hashFn func(T) uint32) Stream[T] {
-	if s.stream == nil {
-		panic(PanicMissingChannel)
+	if s.isNil() {
+		panic(ErrMissingChannel)
 	}
 
+	h := s.hooks("Distinct")
+	hashFn = instrumentFunction(h, hashFn)
+
+	s = s.Async()
 	outstream := make(chan T, cap(s.stream))
 
 	go func() {
 		defer close(outstream)
+		defer h.close()
+
+		h.start()
+
+		// hash is prefixed with the type in case T is an interface implemented by 2 or more types
+		// that are present on the stream.
+		hash := instrumentInput(h, func(val T) evaluatedElement[T, string] {
+			return evaluatedElement[T, string]{value: val, result: fmt.Sprintf("%T%d", val, hashFn(val))}
+		})
 
 		unique := map[string]struct{}{}
 
-		for val := range s.stream {
-			// hash is prefixed with the type in case T is an interface implemented by 2 or more types
-			// that are present on the stream.
-			uniqueHash := fmt.Sprintf("%T%d", val, hashFn(val))
-			if _, ok := unique[uniqueHash]; !ok {
-				unique[uniqueHash] = struct{}{}
-				outstream <- val
+		keepUnique := func(e evaluatedElement[T, string]) {
+			if _, ok := unique[e.result]; ok {
+				h.dropped()
+				return
 			}
+
+			unique[e.result] = struct{}{}
+			send(h, outstream, e.value)
+		}
+
+		if s.concurrency > 0 {
+			for e := range orderlyConcurrentDo(s, nil, hash) {
+				keepUnique(e)
+			}
+
+			return
+		}
+
+		for val := range s.stream {
+			keepUnique(hash(val))
 		}
 	}()
 
-	return NewConcurrentStream(outstream, s.concurrency)
+	return planStage(s, derivedStream(s, outstream), "Distinct")
 }
 
 // StreamAny returns this stream as a Stream[Any].
 func (s Stream[T]) StreamAny() Stream[Any] {
+	if s.pull != nil {
+		return planStage(s, derivedPullStream(s, pullMap(s.pull, func(el T) Any { return el })), "StreamAny")
+	}
+
 	rCh := make(chan Any, cap(s.stream))
 
-	r := NewConcurrentStream(rCh, s.concurrency)
+	r := planStage(s, derivedStream(s, rCh), "StreamAny")
 
 	go func() {
 		defer close(rCh)
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"hash/crc32"
 	"reflect"
+	"sort"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -245,6 +248,335 @@ func TestStream_FlatMap_Concurrent(t *testing.T) {
 	assert.WithinDuration(t, end, start, 3*time.Second) // 3 seconds should be plenty enough...
 }
 
+func TestStream_MicroBatch(t *testing.T) {
+	const numEntries = 1000
+
+	want := []int{}
+	for i := 0; i < numEntries; i++ {
+		want = append(want, functionTimesTwo(i).(int))
+	}
+
+	tt := map[string]struct {
+		concurrency int
+		batchSize   int
+		bufsize     int
+	}{
+		"no concurrency, no batching":                 {concurrency: 0, batchSize: 0, bufsize: 0},
+		"no concurrency, batches of 8":                {concurrency: 0, batchSize: 8, bufsize: 100},
+		"concurrency of 4, no batching":               {concurrency: 4, batchSize: 1, bufsize: 0},
+		"concurrency of 4, batches of 8":              {concurrency: 4, batchSize: 8, bufsize: 100},
+		"concurrency of 4, batches larger than input": {concurrency: 4, batchSize: 2 * numEntries, bufsize: numEntries},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			data := make([]int, numEntries)
+			for i := range data {
+				data[i] = i
+			}
+
+			s := NewStreamFromSlice(data, tc.bufsize).
+				Concurrent(tc.concurrency).
+				MicroBatch(tc.batchSize)
+
+			assert.Equal(t, tc.concurrency, s.Concurrency())
+
+			got := C(s.Map(functionTimesTwo), Int).ToSlice()
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestStream_MicroBatchSize(t *testing.T) {
+	s := NewStream(make(chan int))
+	assert.Equal(t, 1, s.MicroBatchSize())
+	assert.Equal(t, 1, s.MicroBatch(-3).MicroBatchSize())
+	assert.Equal(t, 16, s.MicroBatch(16).MicroBatchSize())
+	assert.Equal(t, 16, s.MicroBatch(16).Concurrent(4).MicroBatchSize())
+	assert.Equal(t, 16, s.MicroBatch(16).Filter(True[int]()).MicroBatchSize())
+}
+
+// legacyOrderlyConcurrentDo is the former implementation of orderlyConcurrentDo
+// that creates a channel and a goroutine for each element.
+// It is retained as a baseline for benchmarks.
+func legacyOrderlyConcurrentDo[T, U any](s Stream[T], fn Function[T, U]) chan U {
+	outstream := make(chan U, cap(s.stream))
+
+	go func() {
+		defer close(outstream)
+
+		pipelineCh := make(chan chan U, s.concurrency)
+
+		go func() {
+			defer close(pipelineCh)
+
+			for val := range s.stream {
+				resultCh := make(chan U, 1)
+				pipelineCh <- resultCh
+
+				go func(resultCh chan<- U, val T) {
+					defer close(resultCh)
+					resultCh <- fn(val)
+				}(resultCh, val)
+			}
+		}()
+
+		for resultCh := range pipelineCh {
+			outstream <- <-resultCh
+		}
+	}()
+
+	return outstream
+}
+
+func benchmarkOrderlyConcurrentDo(b *testing.B, doer func(Stream[int], Function[int, int]) chan int, fn Function[int, int], concurrency, batchSize int) {
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = i
+	}
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		// the legacy implementation reads the channel of the stream.
+		s := NewStreamFromSlice(data, 100).
+			Async().
+			Concurrent(concurrency).
+			MicroBatch(batchSize)
+
+		for range doer(s, fn) {
+		}
+	}
+}
+
+func BenchmarkOrderlyConcurrentDo(b *testing.B) {
+	fastFn := func(i int) int { return 2 * i }
+	slowFn := func(i int) int {
+		for start := time.Now(); time.Since(start) < time.Microsecond; { //nolint: revive
+		}
+		return 2 * i
+	}
+
+	fns := []struct {
+		name string
+		fn   Function[int, int]
+	}{
+		{name: "fast", fn: fastFn},
+		{name: "slow", fn: slowFn},
+	}
+
+	for _, fn := range fns {
+		for _, concurrency := range []int{1, 4, 16} {
+			b.Run(fmt.Sprintf("legacy/%s/concurrency=%d", fn.name, concurrency), func(b *testing.B) {
+				benchmarkOrderlyConcurrentDo(b, legacyOrderlyConcurrentDo[int, int], fn.fn, concurrency, 1)
+			})
+
+			for _, batchSize := range []int{1, 16, 64} {
+				b.Run(fmt.Sprintf("pool/%s/concurrency=%d/batch=%d", fn.name, concurrency, batchSize), func(b *testing.B) {
+					benchmarkOrderlyConcurrentDo(b, func(s Stream[int], fn Function[int, int]) chan int {
+						return orderlyConcurrentDo(s, nil, fn)
+					}, fn.fn, concurrency, batchSize)
+				})
+			}
+		}
+	}
+}
+
+func TestStream_MapUnordered(t *testing.T) {
+	tt := map[string]struct {
+		stream      chan int
+		concurrency int
+		want        []int
+	}{
+		"Should return an empty Stream": {
+			stream:      nil,
+			concurrency: 2,
+			want:        []int{},
+		},
+		"Should return a Stream of doubled integers with no concurrency": {
+			stream: func() chan int {
+				c := make(chan int)
+				go func() {
+					defer close(c)
+					c <- 1
+					c <- 3
+					c <- 2
+				}()
+				return c
+			}(),
+			concurrency: 0,
+			want:        []int{2, 4, 6},
+		},
+		"Should return a Stream of doubled integers with concurrency": {
+			stream: func() chan int {
+				c := make(chan int)
+				go func() {
+					defer close(c)
+					for i := 1; i <= 10; i++ {
+						c <- i
+					}
+				}()
+				return c
+			}(),
+			concurrency: 3,
+			want:        []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := C(NewConcurrentStream(tc.stream, tc.concurrency).
+				MapUnordered(functionTimesTwo), Int).
+				ToSlice()
+			sort.Ints(got)
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStream_MapUnordered_Concurrent(t *testing.T) {
+	const numEntries = 300
+	const concurrencyLevel = numEntries / 10
+
+	want := []int{}
+	for i := 0; i < numEntries; i++ {
+		want = append(want, functionTimesTwo(i).(int))
+	}
+
+	sourceStream := func() chan int {
+		c := make(chan int, 10)
+		go func() {
+			defer close(c)
+			for i := 0; i < numEntries; i++ {
+				c <- i
+			}
+		}()
+		return c
+	}()
+
+	start := time.Now()
+
+	// functionSlowTimesTwo: use slow function to illustrate the performance improvement
+	result := C(NewStream(sourceStream).
+		Concurrent(concurrencyLevel).
+		MapUnordered(functionSlowTimesTwo), Int).
+		ToSlice()
+
+	end := time.Now()
+
+	sort.Ints(result)
+	assert.Equal(t, want, result)
+
+	// if concurrency is not effective, the test will take 15 seconds.
+	assert.WithinDuration(t, end, start, 3*time.Second) // 3 seconds should be plenty enough...
+}
+
+func TestStream_MapUnordered_NoHeadOfLineBlocking(t *testing.T) {
+	slowFirst := func(i int) Any {
+		if i == 0 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		return i
+	}
+
+	result := C(NewStreamFromSlice([]int{0, 1, 2, 3}, 0).
+		Concurrent(2).
+		MapUnordered(slowFirst), Int).
+		ToSlice()
+
+	assert.Equal(t, []int{1, 2, 3, 0}, result)
+}
+
+func TestStream_FlatMapUnordered(t *testing.T) {
+	sliceOfSlicesOfInts := [][]int{{1, 2, 3}, {4, 5}, {6, 7, 8}}
+
+	tt := map[string]struct {
+		stream      Stream[[]int]
+		concurrency int
+		want        []int
+	}{
+		"Should return an empty Stream": {
+			stream:      Stream[[]int]{stream: nil},
+			concurrency: 2,
+			want:        []int{},
+		},
+		"Should return a flattened Stream with no concurrency": {
+			stream:      NewStreamFromSlice(sliceOfSlicesOfInts, 0),
+			concurrency: 0,
+			want:        []int{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+		"Should return a flattened Stream with concurrency": {
+			stream:      NewStreamFromSlice(sliceOfSlicesOfInts, 0),
+			concurrency: 3,
+			want:        []int{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := C(tc.stream.
+				Concurrent(tc.concurrency).
+				FlatMapUnordered(FlattenSlice[int](0)), Int).
+				ToSlice()
+			sort.Ints(got)
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// skewedLatencyTimesTwo is a Function with a skewed latency distribution:
+// one element in ten is much slower than the others.
+var skewedLatencyTimesTwo = func() Function[int, Any] {
+	return func(i int) Any {
+		if i%10 == 0 {
+			time.Sleep(2 * time.Millisecond)
+		} else {
+			time.Sleep(20 * time.Microsecond)
+		}
+		return 2 * i
+	}
+}()
+
+func BenchmarkStream_Map_SkewedLatency(b *testing.B) {
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i
+	}
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		NewStreamFromSlice(data, 10).
+			Concurrent(4).
+			Map(skewedLatencyTimesTwo).
+			ForEach(func(Any) {})
+	}
+}
+
+func BenchmarkStream_MapUnordered_SkewedLatency(b *testing.B) {
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i
+	}
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		NewStreamFromSlice(data, 10).
+			Concurrent(4).
+			MapUnordered(skewedLatencyTimesTwo).
+			ForEach(func(Any) {})
+	}
+}
+
 func TestStream_Filter(t *testing.T) {
 	tt := map[string]struct {
 		stream    chan int
@@ -292,6 +624,39 @@ func TestStream_Filter(t *testing.T) {
 	}
 }
 
+func TestStream_Filter_Concurrent(t *testing.T) {
+	const numEntries = 300
+	const concurrencyLevel = numEntries / 10
+
+	data := make([]int, numEntries)
+	want := []int{}
+	for i := range data {
+		data[i] = i
+		if i%2 == 0 {
+			want = append(want, i)
+		}
+	}
+
+	slowIsEven := func(i int) bool {
+		time.Sleep(50 * time.Millisecond)
+		return i%2 == 0
+	}
+
+	start := time.Now()
+
+	got := NewStreamFromSlice(data, 10).
+		Concurrent(concurrencyLevel).
+		Filter(slowIsEven).
+		ToSlice()
+
+	end := time.Now()
+
+	assert.Equal(t, want, got)
+
+	// if concurrency is not effective, the test will take 15 seconds.
+	assert.WithinDuration(t, end, start, 3*time.Second) // 3 seconds should be plenty enough...
+}
+
 func TestStream_LeftReduce(t *testing.T) {
 	tt := map[string]struct {
 		stream chan string
@@ -342,6 +707,45 @@ func TestStream_LeftReduce(t *testing.T) {
 	}
 }
 
+func TestStream_ReduceOptional(t *testing.T) {
+	tt := map[string]struct {
+		stream Stream[string]
+		want   Optional[string]
+	}{
+		"Should return empty Optional for an empty Stream": {
+			stream: NewStreamFromSlice([]string{}, 0),
+			want:   OptionalEmpty[string](),
+		},
+		"Should return reduction of set of single element": {
+			stream: NewStreamFromSlice([]string{"three"}, 0),
+			want:   OptionalOf("three"),
+		},
+		"Should return reduction of set of multiple elements": {
+			stream: NewStream(chanOf([]string{"four-", "twelve-", "one-", "six-", "three"})),
+			want:   OptionalOf("four-twelve-one-six-three"),
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := tc.stream.ReduceOptional(Concatenate[string])
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStream_OptionalTerminals_PanicWhenNilChannel(t *testing.T) {
+	s := Stream[int]{}
+
+	assert.PanicsWithValue(t, PanicMissingChannel, func() { s.ReduceOptional(Sum[int]) })
+	assert.PanicsWithValue(t, PanicMissingChannel, func() { s.FindFirst() })
+	assert.PanicsWithValue(t, PanicMissingChannel, func() { s.FindAny(True[int]()) })
+	assert.PanicsWithValue(t, PanicMissingChannel, func() { s.LastOptional() })
+	assert.PanicsWithValue(t, PanicMissingChannel, func() { s.HeadOptional() })
+}
+
 func TestStream_Intersperse(t *testing.T) {
 	tt := map[string]struct {
 		stream    chan string
@@ -685,6 +1089,125 @@ func TestStream_AllMatch(t *testing.T) {
 	}
 }
 
+func TestStream_FindFirstMatching(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	tt := map[string]struct {
+		stream    Stream[int]
+		want      Optional[int]
+		wantPeeks []int
+	}{
+		"Should return empty Optional for an empty Stream": {
+			stream: NewStreamFromSlice([]int{}, 0),
+			want:   OptionalEmpty[int](),
+		},
+		"Should return empty Optional when no element matches": {
+			stream:    NewStreamFromSlice([]int{1, 3, 5}, 0),
+			want:      OptionalEmpty[int](),
+			wantPeeks: []int{1, 3, 5},
+		},
+		"Should return first matching element and stop": {
+			stream:    NewStreamFromSlice([]int{1, 2, 3, 4}, 0),
+			want:      OptionalOf(2),
+			wantPeeks: []int{1, 2},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			if DisableFusion {
+				// the stages run ahead of the terminal operation in their own Go routine.
+				assert.Equal(t, tc.want, tc.stream.FindFirstMatching(isEven))
+				return
+			}
+
+			var peeks []int
+
+			got := tc.stream.Peek(func(i int) { peeks = append(peeks, i) }).FindFirstMatching(isEven)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantPeeks, peeks)
+		})
+	}
+}
+
+func TestStream_FindFirst(t *testing.T) {
+	assert.Equal(t, OptionalEmpty[int](), NewStreamFromSlice([]int{}, 0).FindFirst())
+	assert.Equal(t, OptionalOf(7), NewStreamFromSlice([]int{7, 8}, 0).FindFirst())
+	assert.Equal(t, OptionalOf(7), NewStream(chanOf([]int{7, 8})).FindFirst())
+	assert.Equal(t, OptionalOf(7), NewStreamFromSlice([]int{7, 8}, 0).HeadOptional())
+}
+
+func TestStream_FindAny(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	tt := map[string]struct {
+		stream Stream[int]
+		want   []Optional[int]
+	}{
+		"Should return empty Optional for an empty Stream": {
+			stream: NewStreamFromSlice([]int{}, 0).Concurrent(3),
+			want:   []Optional[int]{OptionalEmpty[int]()},
+		},
+		"Should return empty Optional when no element matches": {
+			stream: NewStreamFromSlice([]int{1, 3, 5}, 0).Concurrent(3),
+			want:   []Optional[int]{OptionalEmpty[int]()},
+		},
+		"Should return any matching element": {
+			stream: NewStreamFromSlice(intRange(100), 0).Concurrent(3),
+			want:   []Optional[int]{OptionalOf(0), OptionalOf(2), OptionalOf(4), OptionalOf(6)},
+		},
+		"Should return matching element of non-concurrent Stream": {
+			stream: NewStream(chanOf([]int{1, 3, 4})),
+			want:   []Optional[int]{OptionalOf(4)},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := tc.stream.FindAny(func(i int) bool { return i < 8 && isEven(i) })
+			assert.Contains(t, tc.want, got)
+		})
+	}
+}
+
+func TestStream_FindAny_StopsReadingStreamOnceFound(t *testing.T) {
+	c := make(chan int)
+	stop := make(chan struct{})
+	defer close(stop)
+
+	var sent, evaluated int64
+
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case c <- i:
+				atomic.AddInt64(&sent, 1)
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	got := NewStream(c).Concurrent(3).FindAny(func(i int) bool {
+		atomic.AddInt64(&evaluated, 1)
+		return i == 5
+	})
+	assert.Equal(t, OptionalOf(5), got)
+
+	// the workers stop: the user channel is neither drained nor evaluated any further.
+	time.Sleep(50 * time.Millisecond)
+	sentAfter, evaluatedAfter := atomic.LoadInt64(&sent), atomic.LoadInt64(&evaluated)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, sentAfter, atomic.LoadInt64(&sent))
+	assert.Equal(t, evaluatedAfter, atomic.LoadInt64(&evaluated))
+	assert.LessOrEqual(t, evaluatedAfter, sentAfter)
+}
+
 func TestStream_Drop(t *testing.T) {
 	data1 := []any{
 		1,
@@ -910,6 +1433,52 @@ func TestStream_LastX_PanicsWhenEmptyChannel(t *testing.T) {
 	assert.PanicsWithValue(t, PanicNoSuchElement, func() { NewStream(emptyStream()).Last() })
 }
 
+func TestStream_LastOptional(t *testing.T) {
+	assert.Equal(t, OptionalEmpty[int](), NewStreamFromSlice([]int{}, 0).LastOptional())
+	assert.Equal(t, OptionalOf(3), NewStreamFromSlice([]int{1, 2, 3}, 0).LastOptional())
+	assert.Equal(t, OptionalOf(3), NewStream(chanOf([]int{1, 2, 3})).LastOptional())
+}
+
+func TestStream_TryLastN(t *testing.T) {
+	tt := map[string]struct {
+		stream  Stream[int]
+		n       uint64
+		want    []int
+		wantErr error
+	}{
+		"Should return error for a Stream of nil": {
+			stream:  Stream[int]{},
+			n:       1,
+			wantErr: ErrMissingChannel,
+		},
+		"Should return error for an empty Stream": {
+			stream:  NewStreamFromSlice([]int{}, 0),
+			n:       1,
+			wantErr: ErrNoSuchElement,
+		},
+		"Should return error when n is 0": {
+			stream:  NewStreamFromSlice([]int{1}, 0),
+			n:       0,
+			wantErr: ErrNoSuchElement,
+		},
+		"Should return the last elements": {
+			stream: NewStreamFromSlice([]int{1, 2, 3}, 0),
+			n:      2,
+			want:   []int{2, 3},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.stream.TryLastN(tc.n)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestStream_LastNWithInvalidArgumentPanics(t *testing.T) {
 	tt := map[string]struct {
 		n         uint64
@@ -1576,6 +2145,124 @@ func TestStream_ForEach(t *testing.T) {
 	}
 }
 
+func TestStream_ForEachConcurrent(t *testing.T) {
+	tt := map[string]struct {
+		stream chan int
+		n      int
+		want   int
+	}{
+		"Should not call the consumer when nil in-stream": {
+			stream: nil,
+			n:      4,
+			want:   0,
+		},
+		"Should call the consumer for all elements with a single worker": {
+			stream: NewStreamFromSlice([]int{1, 2, 3, 5, 8}, 0).Async().stream,
+			n:      0,
+			want:   19,
+		},
+		"Should call the consumer for all elements with several workers": {
+			stream: NewStreamFromSlice([]int{1, 2, 3, 5, 8}, 0).Async().stream,
+			n:      3,
+			want:   19,
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			var total int64
+
+			NewStream(tc.stream).ForEachConcurrent(tc.n, func(i int) {
+				atomic.AddInt64(&total, int64(i))
+			})
+
+			assert.EqualValues(t, tc.want, atomic.LoadInt64(&total))
+		})
+	}
+}
+
+func TestStream_ForEachConcurrent_IsConcurrent(t *testing.T) {
+	const numEntries = 300
+
+	data := make([]int, numEntries)
+
+	var count int64
+
+	start := time.Now()
+
+	NewStreamFromSlice(data, 10).ForEachConcurrent(numEntries/10, func(int) {
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt64(&count, 1)
+	})
+
+	end := time.Now()
+
+	assert.EqualValues(t, numEntries, atomic.LoadInt64(&count))
+
+	// if concurrency is not effective, the test will take 15 seconds.
+	assert.WithinDuration(t, end, start, 3*time.Second) // 3 seconds should be plenty enough...
+}
+
+func TestStream_ForEachConcurrent_HonoursStreamConcurrency(t *testing.T) {
+	const workers = 4
+
+	// the consumers can only pass the barrier when they are all running concurrently.
+	barrier := sync.WaitGroup{}
+	barrier.Add(workers)
+
+	var total int64
+
+	s := NewStreamFromSlice(intRange(workers), 0).Concurrent(workers)
+	s.ForEachConcurrent(s.Concurrency(), func(i int) {
+		barrier.Done()
+		barrier.Wait()
+		atomic.AddInt64(&total, int64(i))
+	})
+
+	assert.EqualValues(t, 6, atomic.LoadInt64(&total))
+}
+
+func TestStream_ForEachConcurrent_ResumesPanicOnConsumer(t *testing.T) {
+	var consumed int64
+
+	s := NewStreamFromSlice(intRange(1000), 0)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		s.ForEachConcurrent(2, func(i int) {
+			if i == 500 {
+				panic("boom")
+			}
+
+			atomic.AddInt64(&consumed, 1)
+		})
+	})
+
+	// the workers stop consuming once the consumer panicked.
+	assert.Less(t, atomic.LoadInt64(&consumed), int64(999))
+
+	select {
+	case <-s.releaser.released():
+	default:
+		t.Error("the source was not released")
+	}
+}
+
+func TestStream_ForEach_IsSequentialAndOrderedOnConcurrentStream(t *testing.T) {
+	got := []int{}
+
+	Map(NewStreamFromSlice(intRange(500), 10).Concurrent(4), func(i int) int { return i * 2 }).
+		ForEach(func(i int) { got = append(got, i) })
+
+	want := make([]int, 500)
+	for i := range want {
+		want[i] = i * 2
+	}
+
+	assert.Equal(t, want, got)
+}
+
 func TestStream_ToSlice(t *testing.T) {
 	tt := map[string]struct {
 		stream chan int
@@ -1711,6 +2398,22 @@ func TestStream_Distinct(t *testing.T) {
 	}
 }
 
+func TestStream_Distinct_Concurrent(t *testing.T) {
+	data := []int{1, 2, 1, 3, 2, 4, 5, 5, 6, 1}
+
+	slowHash := func(i int) uint32 {
+		time.Sleep(10 * time.Millisecond)
+		return uint32(i)
+	}
+
+	got := NewStreamFromSlice(data, 0).
+		Concurrent(4).
+		Distinct(slowHash).
+		ToSlice()
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, got)
+}
+
 func TestStream_Peek(t *testing.T) {
 	computeSumTotal := func(callCount, total *int) Consumer[int] {
 		return func(value int) {
@@ -1790,6 +2493,38 @@ func TestStream_Peek(t *testing.T) {
 	}
 }
 
+func TestStream_Peek_Concurrent(t *testing.T) {
+	const numEntries = 300
+	const concurrencyLevel = numEntries / 10
+
+	data := make([]int, numEntries)
+	for i := range data {
+		data[i] = i
+	}
+
+	var callCount int64
+
+	slowConsumer := func(int) {
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt64(&callCount, 1)
+	}
+
+	start := time.Now()
+
+	got := NewStreamFromSlice(data, 10).
+		Concurrent(concurrencyLevel).
+		Peek(slowConsumer).
+		ToSlice()
+
+	end := time.Now()
+
+	assert.Equal(t, data, got)
+	assert.EqualValues(t, numEntries, atomic.LoadInt64(&callCount))
+
+	// if concurrency is not effective, the test will take 15 seconds.
+	assert.WithinDuration(t, end, start, 3*time.Second) // 3 seconds should be plenty enough...
+}
+
 var float2int = func() Function[float32, Any] {
 	return func(f float32) Any {
 		return int(f)
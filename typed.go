@@ -0,0 +1,217 @@
+package fuego
+
+// NOTICE:
+// The functions in this file are typed counterparts of the methods of Stream that
+// would require parameterised methods, which Go does not support. See doc.go.
+//
+// Unlike the methods of Stream combined with casting functions such as C, SC, CC
+// and MC, these functions do not incur a type assertion nor an extra Go routine and
+// channel per cast.
+
+// Map returns a Stream consisting of the result of
+// applying the given function to the elements of the stream.
+//
+// It is the typed counterpart of Stream.Map: execution is concurrent
+// (as per the stream's concurrency level) and order is preserved.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func Map[T, R any](s Stream[T], mapper Function[T, R]) Stream[R] {
+	h := s.hooks("Map")
+
+	if s.concurrency == 0 {
+		mapper = instrumentFunction(h, mapper)
+
+		return planStage(s, fuse(s, h, func(pull func() (T, bool)) func() (R, bool) {
+			return pullMap(pull, mapper)
+		}), "Map")
+	}
+
+	return planStage(s, derivedStream(s, orderlyConcurrentDo(s, h, mapper)), "Map")
+}
+
+// FlatMap takes a StreamFunction to flatten the entries
+// in the stream and produce a new stream.
+//
+// It is the typed counterpart of Stream.FlatMap: execution is concurrent
+// (as per the stream's concurrency level) and order is preserved.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func FlatMap[T, R any](s Stream[T], mapper StreamFunction[T, R]) Stream[R] {
+	return planStage(s, derivedStream(s, orderlyConcurrentDoStream(s, s.hooks("FlatMap"), mapper)), "FlatMap")
+}
+
+// MapUnordered is the typed counterpart of Stream.MapUnordered.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func MapUnordered[T, R any](s Stream[T], mapper Function[T, R]) Stream[R] {
+	return planStage(s, derivedStream(s, unorderedConcurrentDo(s, s.hooks("MapUnordered"), mapper)), "MapUnordered")
+}
+
+// FlatMapUnordered is the typed counterpart of Stream.FlatMapUnordered.
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func FlatMapUnordered[T, R any](s Stream[T], mapper StreamFunction[T, R]) Stream[R] {
+	return planStage(s, derivedStream(s, unorderedConcurrentDoStream(s, s.hooks("FlatMapUnordered"), mapper)), "FlatMapUnordered")
+}
+
+// Scan returns a Stream of the successive accumulations of the elements of the stream,
+// starting from the seed value (which itself is not emitted).
+//
+// Example: Scan of [1, 2, 3] with seed 0 and Sum yields [1, 3, 6].
+//
+// This function streams continuously until the in-stream is closed at
+// which point the out-stream will be closed too.
+func Scan[T, A any](s Stream[T], seed A, accumulator BiFunction[A, T, A]) Stream[A] {
+	s = s.Async()
+	outstream := make(chan A, cap(s.stream))
+
+	go func() {
+		defer close(outstream)
+
+		if s.stream == nil {
+			return
+		}
+
+		acc := seed
+
+		for val := range s.stream {
+			acc = accumulator(acc, val)
+			outstream <- acc
+		}
+	}()
+
+	return planStage(s, derivedStream(s, outstream), "Scan")
+}
+
+// Fold accumulates the elements of the stream by applying the given function,
+// starting from the seed value.
+//
+// Unlike Stream.LeftReduce, the type of the result may differ from that of the elements
+// and an empty stream yields the seed.
+//
+// This is a continuous terminal operation. It will only complete if the producer closes the stream.
+func Fold[T, A any](s Stream[T], seed A, accumulator BiFunction[A, T, A]) A {
+	acc := seed
+
+	s.ForEach(func(val T) {
+		acc = accumulator(acc, val)
+	})
+
+	return acc
+}
+
+// FoldRight accumulates the elements of the stream by applying the given function,
+// starting from the seed value and from the last element of the stream.
+//
+// The elements are buffered until the stream is closed, after which they are folded
+// from the last to the first. An empty stream yields the seed.
+//
+// This is a continuous terminal operation. It will only complete if the producer closes the stream.
+func FoldRight[T, A any](s Stream[T], seed A, accumulator BiFunction[T, A, A]) A {
+	elements := s.ToSlice()
+
+	acc := seed
+	for i := len(elements) - 1; i >= 0; i-- {
+		acc = accumulator(elements[i], acc)
+	}
+
+	return acc
+}
+
+// FoldWhile accumulates the elements of the stream by applying the given function,
+// starting from the seed value, as long as the accumulated value satisfies the predicate.
+//
+// It stops as soon as the accumulated value (including the seed) does not satisfy the
+// predicate and returns it. The remaining elements of the stream are then released
+// (see Stream.All).
+//
+// This is a short-circuiting terminal operation.
+func FoldWhile[T, A any](s Stream[T], seed A, accumulator BiFunction[A, T, A], p Predicate[A]) A {
+	acc := seed
+
+	if s.isNil() {
+		return acc
+	}
+
+	if !p(acc) {
+		s.release()
+		return acc
+	}
+
+	for val, ok := s.receive(); ok; val, ok = s.receive() {
+		acc = accumulator(acc, val)
+
+		if !p(acc) {
+			s.release()
+			break
+		}
+	}
+
+	return acc
+}
+
+// Stage is a step of a pipeline that transforms a Stream[T] into a Stream[R].
+//
+// Stages are composed with Through, Pipe2, Pipe3 and Pipe4.
+type Stage[T, R any] func(Stream[T]) Stream[R]
+
+// MapStage returns a Stage that performs Map with the given mapper.
+func MapStage[T, R any](mapper Function[T, R]) Stage[T, R] {
+	return func(s Stream[T]) Stream[R] {
+		return Map(s, mapper)
+	}
+}
+
+// FlatMapStage returns a Stage that performs FlatMap with the given mapper.
+func FlatMapStage[T, R any](mapper StreamFunction[T, R]) Stage[T, R] {
+	return func(s Stream[T]) Stream[R] {
+		return FlatMap(s, mapper)
+	}
+}
+
+// FilterStage returns a Stage that performs Stream.Filter with the given predicate.
+func FilterStage[T any](predicate Predicate[T]) Stage[T, T] {
+	return func(s Stream[T]) Stream[T] {
+		return s.Filter(predicate)
+	}
+}
+
+// Through applies the given stages, left-to-right, to the stream.
+//
+// All stages must preserve the type of the elements. See Pipe2, Pipe3 and Pipe4 to
+// compose stages that change the type of the elements.
+func Through[T any](s Stream[T], stages ...Stage[T, T]) Stream[T] {
+	for _, stage := range stages {
+		s = stage(s)
+	}
+
+	return s
+}
+
+// Pipe2 applies the given stages, left-to-right, to the stream.
+//
+// Example:
+//
+//	Pipe2(s, MapStage(float2int), MapStage(int2string)) // Stream[float32] -> Stream[string]
+func Pipe2[T, U, R any](s Stream[T], stage1 Stage[T, U], stage2 Stage[U, R]) Stream[R] {
+	return stage2(stage1(s))
+}
+
+// Pipe3 applies the given stages, left-to-right, to the stream.
+//
+// See Pipe2 for an example.
+func Pipe3[T, U, V, R any](s Stream[T], stage1 Stage[T, U], stage2 Stage[U, V], stage3 Stage[V, R]) Stream[R] {
+	return stage3(stage2(stage1(s)))
+}
+
+// Pipe4 applies the given stages, left-to-right, to the stream.
+//
+// For more stages, nest calls to PipeX functions.
+// See Pipe2 for an example.
+func Pipe4[T, U, V, W, R any](s Stream[T], stage1 Stage[T, U], stage2 Stage[U, V], stage3 Stage[V, W], stage4 Stage[W, R]) Stream[R] {
+	return stage4(stage3(stage2(stage1(s))))
+}
@@ -0,0 +1,251 @@
+package fuego
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	tt := map[string]struct {
+		stream Stream[int]
+		want   []string
+	}{
+		"Should return an empty Stream when nil in-stream": {
+			stream: Stream[int]{stream: nil},
+			want:   []string{},
+		},
+		"Should map the elements in order": {
+			stream: NewStreamFromSlice([]int{1, 3, 2}, 0),
+			want:   []string{"1", "3", "2"},
+		},
+		"Should map the elements in order when concurrent": {
+			stream: NewStreamFromSlice([]int{1, 3, 2, 5, 4, 7, 6}, 0).Concurrent(3),
+			want:   []string{"1", "3", "2", "5", "4", "7", "6"},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := Map(tc.stream, strconv.Itoa)
+			assert.Equal(t, tc.stream.Concurrency(), got.Concurrency())
+			assert.Equal(t, tc.want, got.ToSlice())
+		})
+	}
+}
+
+func TestMap_Chained(t *testing.T) {
+	double := func(i int) int { return 2 * i }
+	length := func(s string) int { return len(s) }
+
+	got := Map(Map(Map(NewStreamFromSlice([]int{1, 50, 500}, 0), double), strconv.Itoa), length).ToSlice()
+	assert.Equal(t, []int{1, 3, 4}, got)
+}
+
+func TestFlatMap(t *testing.T) {
+	got := FlatMap(NewStreamFromSlice([][]int{{1, 2, 3}, {4, 5}, {6, 7, 8}}, 0).Concurrent(2), FlattenTypedSlice[int](0)).
+		ToSlice()
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, got)
+}
+
+func TestMapUnordered(t *testing.T) {
+	got := MapUnordered(NewStreamFromSlice([]int{1, 3, 2}, 0).Concurrent(2), strconv.Itoa).ToSlice()
+	assert.ElementsMatch(t, []string{"1", "3", "2"}, got)
+}
+
+func TestFlatMapUnordered(t *testing.T) {
+	got := FlatMapUnordered(NewStreamFromSlice([][]int{{1, 2, 3}, {4, 5}, {6, 7, 8}}, 0).Concurrent(2), FlattenTypedSlice[int](0)).
+		ToSlice()
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, got)
+}
+
+func TestScan(t *testing.T) {
+	tt := map[string]struct {
+		stream Stream[int]
+		want   []int
+	}{
+		"Should return an empty Stream when nil in-stream": {
+			stream: Stream[int]{stream: nil},
+			want:   []int{},
+		},
+		"Should return an empty Stream when empty in-stream": {
+			stream: NewStreamFromSlice([]int{}, 0),
+			want:   []int{},
+		},
+		"Should return running totals": {
+			stream: NewStreamFromSlice([]int{1, 2, 3, 4}, 0),
+			want:   []int{11, 13, 16, 20},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := Scan(tc.stream, 10, Sum[int]).ToSlice()
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFold(t *testing.T) {
+	concatenate := func(acc string, i int) string { return acc + strconv.Itoa(i) }
+
+	tt := map[string]struct {
+		stream Stream[int]
+		want   string
+	}{
+		"Should return the seed when nil in-stream": {
+			stream: Stream[int]{stream: nil},
+			want:   ">",
+		},
+		"Should return the seed when empty in-stream": {
+			stream: NewStreamFromSlice([]int{}, 0),
+			want:   ">",
+		},
+		"Should fold the elements left-to-right": {
+			stream: NewStreamFromSlice([]int{1, 2, 3}, 0),
+			want:   ">123",
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := Fold(tc.stream, ">", concatenate)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFoldRight(t *testing.T) {
+	concatenate := func(i int, acc string) string { return acc + strconv.Itoa(i) }
+
+	tt := map[string]struct {
+		stream Stream[int]
+		want   string
+	}{
+		"Should return the seed when nil in-stream": {
+			stream: Stream[int]{stream: nil},
+			want:   "<",
+		},
+		"Should return the seed when empty in-stream": {
+			stream: NewStreamFromSlice([]int{}, 0),
+			want:   "<",
+		},
+		"Should fold the elements right-to-left": {
+			stream: NewStream(chanOf([]int{1, 2, 3})),
+			want:   "<321",
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			got := FoldRight(tc.stream, "<", concatenate)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFoldWhile(t *testing.T) {
+	sum := func(acc, i int) int { return acc + i }
+	lessThan10 := func(acc int) bool { return acc < 10 }
+
+	tt := map[string]struct {
+		stream    Stream[int]
+		seed      int
+		want      int
+		wantPeeks []int
+	}{
+		"Should return the seed when nil in-stream": {
+			stream: Stream[int]{stream: nil},
+			want:   0,
+		},
+		"Should return the seed when it does not satisfy the predicate": {
+			stream: NewStreamFromSlice([]int{1, 2}, 0),
+			seed:   10,
+			want:   10,
+		},
+		"Should fold all the elements when the predicate remains satisfied": {
+			stream:    NewStreamFromSlice([]int{1, 2, 3}, 0),
+			want:      6,
+			wantPeeks: []int{1, 2, 3},
+		},
+		"Should stop when the predicate is not satisfied": {
+			stream:    NewStreamFromSlice([]int{4, 5, 6, 7}, 0),
+			want:      15,
+			wantPeeks: []int{4, 5, 6},
+		},
+	}
+
+	for name, tc := range tt {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			if DisableFusion {
+				// the stages run ahead of the terminal operation in their own Go routine.
+				assert.Equal(t, tc.want, FoldWhile(tc.stream, tc.seed, sum, lessThan10))
+				return
+			}
+
+			var peeks []int
+
+			s := tc.stream
+			if !s.isNil() {
+				s = s.Peek(func(i int) { peeks = append(peeks, i) })
+			}
+
+			got := FoldWhile(s, tc.seed, sum, lessThan10)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantPeeks, peeks)
+		})
+	}
+}
+
+func TestThrough(t *testing.T) {
+	isOdd := func(i int) bool { return i%2 == 1 }
+	double := func(i int) int { return 2 * i }
+
+	got := Through(NewStreamFromSlice([]int{1, 2, 3, 4, 5}, 0),
+		FilterStage(isOdd),
+		MapStage(double),
+		FilterStage(intGreaterThanPredicate(5)),
+	).ToSlice()
+
+	assert.Equal(t, []int{6, 10}, got)
+
+	assert.Equal(t, []int{1, 2}, Through(NewStreamFromSlice([]int{1, 2}, 0)).ToSlice())
+}
+
+func TestPipeX(t *testing.T) {
+	double := func(i int) int { return 2 * i }
+	length := func(s string) int { return len(s) }
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	got2 := Pipe2(NewStreamFromSlice([]int{1, 50, 500}, 0),
+		MapStage(double),
+		MapStage(strconv.Itoa),
+	).ToSlice()
+	assert.Equal(t, []string{"2", "100", "1000"}, got2)
+
+	got3 := Pipe3(NewStreamFromSlice([]int{1, 50, 500}, 0),
+		MapStage(double),
+		MapStage(strconv.Itoa),
+		MapStage(length),
+	).ToSlice()
+	assert.Equal(t, []int{1, 3, 4}, got3)
+
+	got4 := Pipe4(NewStreamFromSlice([][]int{{1, 50}, {500}}, 0),
+		FlatMapStage(FlattenTypedSlice[int](0)),
+		MapStage(double),
+		MapStage(strconv.Itoa),
+		Stage[string, int](func(s Stream[string]) Stream[int] { return Map(s, length).Filter(isEven) }),
+	).ToSlice()
+	assert.Equal(t, []int{4}, got4)
+}